@@ -1,6 +1,10 @@
 package cassandra
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 )
@@ -61,7 +65,81 @@ func (b *backend) Spec() *operator.Spec {
 			},
 		},
 		Resources: []*operator.Resource2{},
+		Status:    clusterStatus,
+	}
+}
+
+// clusterStatus returns the state of the nodes in the ring and their ownership
+func clusterStatus(ctx context.Context, req *operator.StatusRequest) (*proto.BackendStatus, error) {
+	running := req.Running()
+
+	out, err := req.Exec(ctx, running[0], "nodetool", "status")
+	if err != nil {
+		return nil, err
+	}
+
+	// use the name of the instances instead of the addresses
+	names := map[string]string{}
+	for _, i := range running {
+		names[i.Ip] = i.Name
+	}
+
+	status := &proto.BackendStatus{}
+	for _, node := range parseNodetoolStatus(out) {
+		if name, ok := names[node.Name]; ok {
+			node.Name = name
+		}
+		if !strings.HasPrefix(node.Status, "up") {
+			status.Issues = append(status.Issues, fmt.Sprintf("node %s is %s", node.Name, node.Status))
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+	status.Healthy = len(status.Issues) == 0
+	return status, nil
+}
+
+var (
+	nodeStatus = map[byte]string{
+		'U': "up",
+		'D': "down",
+	}
+	nodeState = map[byte]string{
+		'N': "normal",
+		'L': "leaving",
+		'J': "joining",
+		'M': "moving",
+	}
+)
+
+// parseNodetoolStatus parses the nodes in the output of 'nodetool status'
+func parseNodetoolStatus(out string) []*proto.BackendStatus_Node {
+	nodes := []*proto.BackendStatus_Node{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || len(fields[0]) != 2 {
+			continue
+		}
+		status, ok := nodeStatus[fields[0][0]]
+		if !ok {
+			continue
+		}
+		state, ok := nodeState[fields[0][1]]
+		if !ok {
+			continue
+		}
+		node := &proto.BackendStatus_Node{
+			Name:   fields[1],
+			Status: status + "/" + state,
+		}
+		for _, f := range fields[2:] {
+			if strings.HasSuffix(f, "%") {
+				node.Details = "owns " + f
+				break
+			}
+		}
+		nodes = append(nodes, node)
 	}
+	return nodes
 }
 
 // Client implements the Handler interface
@@ -3,6 +3,7 @@ package cassandra
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/testutil"
@@ -52,6 +53,26 @@ func TestBootstrap(t *testing.T) {
 	})
 }
 
+func TestParseNodetoolStatus(t *testing.T) {
+	out := `Datacenter: datacenter1
+=======================
+Status=Up/Down
+|/ State=Normal/Leaving/Joining/Moving
+--  Address     Load       Tokens       Owns (effective)  Host ID                               Rack
+UN  172.17.0.2  103.67 KiB  256          51.2%             8d1c4a6e-8a5e-4f4c-9d8e-6b0f5a0f6d7e  rack1
+DJ  172.17.0.3  ?          256          48.8%             1f2e3d4c-5b6a-7980-a1b2-c3d4e5f6a7b8  rack1
+`
+	nodes := parseNodetoolStatus(out)
+	if assert.Len(t, nodes, 2) {
+		assert.Equal(t, nodes[0].Name, "172.17.0.2")
+		assert.Equal(t, nodes[0].Status, "up/normal")
+		assert.Equal(t, nodes[0].Details, "owns 51.2%")
+
+		assert.Equal(t, nodes[1].Status, "down/joining")
+		assert.Equal(t, nodes[1].Details, "owns 48.8%")
+	}
+}
+
 func TestE2E(t *testing.T) {
 	testutil.IsE2EEnabled(t)
 
@@ -78,7 +78,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _resourcesClusterTemplate = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x55\xcf\x6f\xeb\x36\x0c\xbe\xe7\xaf\xd0\x82\x5d\x6b\xc5\xed\xeb\x5b\x52\xa8\xea\x69\xc0\x0e\xdb\x30\x6c\xb7\x5d\x04\x45\x62\x12\x21\xb2\xe4\x51\x72\x9a\x36\xe8\xff\x3e\xc8\xb2\x1d\x3b\x3f\x1e\x7a\x68\x48\x7e\x1f\x29\x52\xfa\x68\xf6\x76\xac\x2c\x39\x00\x06\xe3\xdd\xeb\xbc\x2c\x16\xf3\x37\x3e\x63\xca\x57\xb5\x74\x1f\x7c\x46\x08\x21\xcc\xfa\xed\x16\x30\x1b\xd9\x01\x07\xb0\x5c\xc3\xba\xd9\x32\x9a\x8d\x73\x50\x79\x17\xbc\x05\x1e\xb1\x01\x46\x7b\x6b\x44\xf6\x5b\x82\x50\xf9\x03\xbc\xce\xf3\xff\x39\x1d\x85\x01\xd1\xe3\x7d\x0c\xa3\xfd\x69\xb2\xf9\x5f\x03\xf8\x21\xac\xdf\x8e\x52\x68\x19\xe5\x5a\x06\xe0\xe1\x23\x44\xa8\x18\x1d\x1c\x67\x4c\x94\x6b\x0b\x7c\x60\x33\x9a\x1d\x5d\x8d\x51\xd6\xec\xf9\xe9\xe1\x81\xfc\x6e\x42\x04\x47\xde\x8d\xd5\x4a\xa2\x26\x52\x6b\x84\x10\x48\xf4\x44\x5a\xeb\xdf\x89\x54\x0a\xea\x68\xdc\x96\x28\xef\x1c\xa8\x68\xbc\x0b\x64\x83\xbe\x22\x3e\xee\x00\x93\x3b\x4a\xe3\x00\x03\x91\x4e\x93\x9d\x0f\x91\x38\x88\xef\x1e\xf7\x05\x79\x78\xe8\xc7\xdd\xd6\x11\x29\xca\x5f\x5e\x18\x1d\xdb\xd7\x88\x45\xd1\xfe\xfd\x00\x16\xf1\x83\x97\x43\x3c\x59\x5d\x53\xbb\x18\x6b\x51\x7b\x8c\x7c\x59\x3e\x3e\x31\x7a\xb6\x73\x3c\xaa\xce\x5c\x2d\x16\x0b\x46\x07\x33\x47\x8d\x8b\x80\x01\xf0\x00\x28\x5a\x66\x5b\xf9\x74\x2a\xfe\x94\x15\x7c\x7d\x31\x7a\x1b\x70\x87\xdc\xd7\x59\xdd\xe0\xe5\xa2\x99\x58\xc9\xa3\x18\x4d\x97\x7f\x5b\xac\xbe\x33\x7a\xe9\xcd\xd8\x3d\x40\x2d\xa4\x35\x07\x10\xd1\x54\xe0\x9b\xc8\x9f\x18\xbd\xe1\x9d\xa4\x56\x0d\x22\xb8\x28\xd2\x1b\x30\x10\x78\x99\x7a\xbf\x13\xcb\xc4\xc6\x25\xbd\xa4\xb7\x00\x5a\x28\xa9\x76\x20\x82\xf9\x04\xbe\x7c\x5e\xae\x56\x4f\xdf\x9e\x57\x8f\x8c\xde\xc3\xf4\xa5\x71\x3f\xf6\x3e\x3f\x7d\x5f\xfe\xb2\x58\x95\x8f\x6d\xe9\x69\xac\x1b\x44\x2d\xe3\x8e\xd3\x83\x44\x6a\xcd\x9a\x2a\x6b\xd4\x7e\xe7\x9b\x00\x94\xd1\x36\xd4\x5d\x61\x55\x8b\xbb\xc8\x58\xd5\x94\xd1\x01\xd2\x35\x13\x00\xc5\xc6\x58\x08\xf7\x89\x67\x0c\x65\xf4\x92\x30\x3b\xe7\x09\x69\x66\x1b\xb3\xe5\xad\x51\x1c\x2b\x9b\xe1\x83\x3f\x43\x35\x6c\x64\x63\xa3\xa8\xd1\xa7\x3c\xbc\xb3\x19\xbd\x0c\x4c\xe1\x83\xaa\xaf\xf0\x53\xbd\xb3\x74\xcd\x9f\xde\x01\xff\xb5\x41\x5f\x03\xfd\xc3\x07\xe5\xdf\x19\x1d\xfc\xdd\x25\x58\xaf\xf6\x02\x8e\xa0\x9a\xbc\x0c\x36\xd2\x06\x60\xf4\xca\xdf\xc2\x33\x27\x2d\xa7\x08\x22\xbf\xd6\x30\xd9\x82\xed\x06\x15\xca\x36\x21\x02\xf2\xd3\x89\xa0\x74\x5b\x20\x3f\x87\x5d\x5a\x1e\x2f\xaf\xa4\xf8\x27\xfd\x0a\xe4\xeb\x6b\xa0\xb5\xd4\x16\x30\x22\x98\x08\x55\xc2\x67\x66\xf1\x37\xd4\xd6\x28\x79\xc5\xeb\xce\xd3\x06\xf9\x55\x24\x0b\x3e\x2b\x34\xa7\x2c\x7e\x4b\xeb\x27\x09\xf5\xac\xcb\x2b\x46\x2b\xbd\x81\xf1\x97\xc7\xcc\x38\xaf\x81\x09\x9a\xf6\xe5\x4f\x27\x02\x4e\x5f\x75\x46\x87\xd6\x2e\xa2\xe9\x3b\x31\x9d\xd7\xac\x4f\x38\x9d\x6f\x76\x7f\x7a\x9f\x54\x3c\xf9\x26\x39\xaf\x81\x18\xa7\xe1\xf8\x3a\x2f\xe7\xd3\xc3\xf5\x8d\x17\xff\xf6\xc4\xdb\x6d\xe7\x76\x1f\xcb\x65\x79\xd9\x22\xa3\x29\x7f\x7f\xaa\x51\xfd\x5e\xbf\x0a\xfd\xe4\xfa\xbb\x36\x2e\xda\x62\x74\xd2\xdf\xe8\xba\x17\x65\x3f\x9d\xae\x46\x9f\xb2\x7b\xf3\x26\x44\x34\xeb\x26\x82\x16\x5a\x8f\x3f\xb7\x59\xa4\x63\x55\xcb\xb0\x4f\x0b\xaa\x01\xaa\xb5\x9d\x6c\x03\x7a\x95\x26\xfb\x37\x1e\x2b\x19\x45\x50\x3b\xa8\xe4\x7d\xd9\x4f\x60\x49\xfa\x37\x78\xb3\xe1\x2a\xf9\xff\x01\x00\x00\xff\xff\x0d\x55\xc3\xcf\x5c\x08\x00\x00")
+var _resourcesClusterTemplate = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x55\xc1\x6e\xe3\x36\x10\xbd\xe7\x2b\x58\xa3\xd7\x88\x72\xb2\xd9\xc6\xc1\x98\x7b\x2a\xd0\x02\x6d\x51\xb4\xb7\x5e\x08\x9a\x1a\xdb\x84\x29\x52\x25\x29\xc7\x8e\xe1\x7f\x5f\x90\xa2\x65\x49\x8e\x57\x12\x92\xcc\xbc\x37\x33\xe4\x23\x67\x02\xdf\x0e\xb5\x26\x7b\x74\x5e\x59\xb3\x9c\xcd\x8b\x72\xf6\x8d\x3d\x80\xb4\x75\x23\xcc\x91\x3d\x10\x42\x08\x68\xbb\xd9\xa0\xeb\x8c\xf8\x82\xc6\x3d\x6a\x56\xe1\xaa\xdd\x00\xed\x8c\x2b\x28\xad\xf1\x56\x23\x0b\xae\x45\xa0\x17\x6b\x10\x6c\x37\xc4\x61\x6d\xf7\xb8\x9c\x75\xbf\x67\x74\x00\xa3\x73\xd6\xdd\xe7\x00\xbd\xac\xa6\x33\xff\x6f\xd1\x1d\xb9\xb6\x9b\x41\x8a\x4a\x04\xb1\x12\x1e\x99\x3f\xfa\x80\x35\xd0\xde\x71\xe5\x04\xb1\xd2\xc8\xfa\x68\xa0\x9d\x23\xd7\x18\x64\xed\x3c\x3f\x3d\x3e\x92\x3f\x94\x0f\x68\xc8\xbb\xd2\x95\x14\xae\x22\xa2\xaa\x1c\x7a\x4f\x82\x25\x42\x6b\xfb\x4e\x84\x94\xd8\x04\x65\x36\x44\x5a\x63\x50\x06\x65\x8d\x27\x6b\x67\x6b\x62\xc3\x16\x5d\x74\x07\xa1\x0c\x3a\x4f\x84\xa9\xc8\xd6\xfa\x40\x0c\x86\x77\xeb\x76\x05\x79\x7c\xcc\xd5\x75\xaa\xc3\x23\xca\xde\xde\x80\x0e\xed\x5b\x46\x59\xa4\xf7\x07\xb4\xe0\x8e\x6c\xde\xe3\xd1\xca\xda\x6d\x43\x68\x78\x63\x5d\x60\xaf\xf3\xa7\x67\xa0\x57\xbb\xc3\x83\xcc\xe6\xa2\x2c\x4b\xa0\xbd\xd9\xa1\xca\x04\x74\x1e\xdd\x1e\x1d\x4f\x91\x69\xc1\xa7\x53\xf1\x97\xa8\xf1\x7c\x06\xfa\x39\xe1\x4e\xf0\xa5\xce\xe2\x93\xb8\x84\xe5\x35\xd7\xe2\xc0\x07\xea\xb2\x2f\xe5\xe2\x2b\xd0\xa9\xb7\xe3\xee\x10\x1b\x2e\xb4\xda\x23\x0f\xaa\x46\xdb\x06\xf6\x0c\xf4\x13\xef\x28\xb5\x6c\x9d\x43\x13\x78\xbc\x03\x0a\x3d\x9b\x97\x65\x5f\x60\x8a\x75\x81\xad\x89\xfd\x12\xef\x02\x56\x5c\x0a\xb9\x45\xee\xd5\x07\xb2\xd7\x97\xd7\xc5\xe2\xf9\xcb\xcb\xe2\x09\xe8\x3d\xce\xa5\xb4\xdb\x0d\xbd\x2f\xcf\x5f\x5f\x7f\x29\x17\xf3\xa7\x54\x7a\x8c\x65\x21\x1a\x11\xb6\x8c\xee\x85\xa3\x5a\xad\xa8\xd4\x4a\xee\xb6\xb6\xf5\x48\x81\x26\x28\x1f\x61\xdd\xf0\xbb\xcc\x50\x37\x14\x68\x4f\xc9\x9b\xf1\xe8\xf8\x5a\x69\xf4\xf7\x03\xaf\x1c\x0a\x03\x23\xa7\xb9\xe6\xf1\xf1\xa8\xd6\x6a\xc3\x92\x51\x1c\x6a\x0d\x74\xe4\xef\xa8\x15\xae\x45\xab\x03\x6f\x9c\x8d\x79\x58\xb6\x81\x4e\x81\x31\xbd\xef\xea\x1b\xfe\xb8\xdf\x21\x1e\xfe\x87\x35\xc8\x7e\x6d\x9d\x6d\x90\xfe\x69\xbd\xb4\xef\x40\x7b\x7f\x3e\x04\x6d\xe5\x8e\xe3\x01\x65\xdb\x0d\x83\xb5\xd0\x1e\x81\xde\xf8\x13\x3d\xfd\x80\x38\xc0\x02\xf2\xae\x0d\xfc\x68\x0a\xa6\x09\xca\xa5\x6e\x7d\x40\xc7\x4e\x27\xe2\x84\xd9\x20\xf9\xd9\x6f\xe3\xf0\x78\x5b\x92\xe2\xdf\xf8\x97\x27\xe7\x73\x1f\x16\x3f\x48\x84\x41\x80\x0a\x58\x93\xb7\x65\x8e\x2c\xfe\xc1\x46\x2b\x29\x6e\xe2\xf2\x7a\x12\xc8\x1e\x26\x40\xfa\x20\x77\x68\x97\xb2\xf8\x2d\x8e\x9f\xd8\xa8\xd7\xbe\x9c\x3e\x90\x5a\xaf\x8f\xf8\xdb\xba\x2e\xe2\x3a\x06\x86\x0f\xd0\x4b\xf9\xd3\x89\xa0\xa9\x6e\x76\x46\xfb\xad\x4d\x50\xa0\x53\xbd\xf2\x20\x9e\xea\xdb\xb9\x3f\xac\x8d\x5d\x3c\x92\xd5\xd8\x0a\xa3\x4a\xc5\x7f\x17\x70\x54\x20\xc1\xca\x54\x78\x58\xce\xe2\x7e\xa2\x5d\xfc\x1e\x6d\x72\x3e\xcf\xd8\xc3\xe7\x3a\x25\xd6\x0f\x74\xba\xea\x93\x98\x77\xf4\x01\x1a\xd1\xc9\xb6\x81\x5e\x77\x91\xb7\x55\x0b\xe9\xec\xe8\x12\x65\x31\x26\xe2\x00\x1d\xa9\x34\xb8\x34\xe5\xfc\xa2\x71\xae\x71\x49\x99\x3b\x47\xf9\xe0\xd4\xaa\x0d\x58\xf1\xaa\x1a\xfe\xd3\x4e\x9d\x3b\x9a\x0d\xc2\xef\xe2\x08\x6c\x91\x56\x95\x1e\xcd\x14\x7a\x93\x26\xe5\x81\xb5\x75\xb5\x08\xdc\xcb\x2d\xd6\xe2\xfe\xf0\x18\xd1\x3c\x85\xb1\x23\xcf\x10\xa0\xd2\xd6\x8d\x30\x47\xf6\x7d\x00\x0a\xc8\x5d\xb6\xa2\x08\x00\x00")
 
 func resourcesClusterTemplateBytes() ([]byte, error) {
 	return bindataRead(
@@ -93,7 +93,7 @@ func resourcesClusterTemplate() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "resources/cluster.template", size: 2140, mode: os.FileMode(436), modTime: time.Unix(1627312510, 0)}
+	info := bindataFileInfo{name: "resources/cluster.template", size: 2210, mode: os.FileMode(436), modTime: time.Unix(1792427411, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -32,7 +32,10 @@ func (b *backend) Ready(t *proto.Instance) bool {
 func (b *backend) Initialize(nodes []*proto.Instance, target *proto.Instance) (*proto.NodeSpec, error) {
 	sch := b.Spec().Nodetypes[""].Schema
 	data := schema.NewResourceData(&sch, target.Group.Params)
-	zkNode := data.Get("zookeeper").(string)
+	zkNodes, err := parseZookeeper(data.Get("zookeeper").(string))
+	if err != nil {
+		return nil, err
+	}
 
 	replicas := []*Replica{}
 
@@ -49,7 +52,7 @@ func (b *backend) Initialize(nodes []*proto.Instance, target *proto.Instance) (*
 
 	obj := &Cluster{
 		Name:      target.FullName(),
-		Zookeeper: zkNode,
+		Zookeeper: zkNodes,
 		Shards: []*Shard{
 			{
 				Replicas: replicas,
@@ -81,7 +84,8 @@ func (b *backend) Spec() *operator.Spec {
 				Schema: schema.Schema2{
 					Spec: &schema.Record{
 						Fields: map[string]*schema.Field{
-							// reference to the zookeeper so that its available in Initialized
+							// either the name of the zookeeper cluster or a reference
+							// to its connection string (${zookeeper.<name>.connect})
 							"zookeeper": {
 								Type: schema.TypeString,
 							},
@@ -117,8 +121,11 @@ func (b *backend) Spec() *operator.Spec {
 				})
 			}
 
-			spec.DependsOn = []string{
-				zkNode,
+			if !operator.IsReference(zkNode) {
+				// the referenced clusters are set as dependencies by the operator
+				spec.DependsOn = []string{
+					zkNode,
+				}
 			}
 			comp.Spec = proto.MustMarshalAny(&spec)
 			return comp, nil
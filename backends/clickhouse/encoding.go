@@ -2,6 +2,9 @@ package clickhouse
 
 import (
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
 
 	"github.com/teseraio/ensemble/lib/template"
 )
@@ -19,7 +22,36 @@ func runTmpl(name string, obj interface{}) ([]byte, error) {
 type Cluster struct {
 	Name      string
 	Shards    []*Shard
-	Zookeeper string
+	Zookeeper []*ZookeeperNode
+}
+
+type ZookeeperNode struct {
+	Index int
+	Host  string
+	Port  uint64
+}
+
+// parseZookeeper parses the address of the zookeeper nodes. It is either
+// the name of the zookeeper cluster or a list of host:port addresses.
+func parseZookeeper(addrs string) ([]*ZookeeperNode, error) {
+	nodes := []*ZookeeperNode{}
+	for indx, addr := range strings.Split(addrs, ",") {
+		node := &ZookeeperNode{
+			Index: indx + 1,
+			Host:  addr,
+			Port:  2181,
+		}
+		if host, port, err := net.SplitHostPort(addr); err == nil {
+			num, err := strconv.ParseUint(port, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("incorrect zookeeper port '%s'", port)
+			}
+			node.Host = host
+			node.Port = num
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
 }
 
 type Shard struct {
@@ -28,3 +28,28 @@ func TestX(t *testing.T) {
 	assert.NoError(t, err)
 	fmt.Println(string(res))
 }
+
+func TestParseZookeeper(t *testing.T) {
+	// name of the zookeeper cluster
+	nodes, err := parseZookeeper("zk1")
+	assert.NoError(t, err)
+	assert.Equal(t, nodes, []*ZookeeperNode{
+		{Index: 1, Host: "zk1", Port: 2181},
+	})
+
+	// connection string from the zookeeper outputs
+	nodes, err = parseZookeeper("zk1-1:2181,zk1-2:2182")
+	assert.NoError(t, err)
+	assert.Equal(t, nodes, []*ZookeeperNode{
+		{Index: 1, Host: "zk1-1", Port: 2181},
+		{Index: 2, Host: "zk1-2", Port: 2182},
+	})
+
+	_, err = parseZookeeper("zk1-1:abc")
+	assert.Error(t, err)
+
+	res, err := runTmpl("cluster", Cluster{Zookeeper: nodes})
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), `<node index="2">`)
+	assert.Contains(t, string(res), `<host>zk1-2</host>`)
+}
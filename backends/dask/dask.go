@@ -1,6 +1,11 @@
 package dask
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 )
@@ -63,7 +68,53 @@ func (b *backend) Spec() *operator.Spec {
 				DefaultVersion: "2.30.0",
 				Volumes:        []*operator.Volume{},
 				Ports:          []*operator.Port{},
+				DependsOn:      []string{"scheduler"},
 			},
 		},
+		Metrics: map[string]func(req *operator.MetricRequest) (float64, error){
+			"queue_length": queueLength,
+		},
+	}
+}
+
+// queueLength returns the number of tasks in the scheduler that are
+// waiting for their dependencies or processing in the workers
+func queueLength(req *operator.MetricRequest) (float64, error) {
+	schedulers := req.Healthy("scheduler")
+	if len(schedulers) == 0 {
+		return 0, fmt.Errorf("no healthy scheduler found")
+	}
+	counts, err := schedulerCounts("http://" + schedulers[0].Ip + ":8787")
+	if err != nil {
+		return 0, err
+	}
+	return float64(counts.Waiting + counts.Processing), nil
+}
+
+// taskCounts are the counters of the tasks reported by the scheduler
+type taskCounts struct {
+	Waiting    int64 `json:"waiting"`
+	Processing int64 `json:"processing"`
+}
+
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// schedulerCounts queries the counters of the scheduler in the dashboard server
+func schedulerCounts(addr string) (*taskCounts, error) {
+	resp, err := httpClient.Get(addr + "/json/counts.json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	var counts taskCounts
+	if err := json.NewDecoder(resp.Body).Decode(&counts); err != nil {
+		return nil, err
 	}
+	return &counts, nil
 }
@@ -1,8 +1,11 @@
 package dask
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/testutil"
@@ -58,6 +61,25 @@ func TestBootstrap(t *testing.T) {
 	})
 }
 
+func TestSchedulerCounts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/counts.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"processing": 8, "waiting": 4, "released": 2, "workers": 2}`))
+	}))
+	defer srv.Close()
+
+	counts, err := schedulerCounts(srv.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, counts.Processing, int64(8))
+	assert.Equal(t, counts.Waiting, int64(4))
+
+	_, err = schedulerCounts(srv.URL + "/other")
+	assert.Error(t, err)
+}
+
 func TestE2E(t *testing.T) {
 	testutil.IsE2EEnabled(t)
 
@@ -1,8 +1,10 @@
 package kafka
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	gproto "github.com/golang/protobuf/proto"
 	"github.com/teseraio/ensemble/operator"
@@ -39,11 +41,15 @@ func (b *backend) Initialize(nodes []*proto.Instance, target *proto.Instance) (*
 
 	sch := b.Spec().Nodetypes[""].Schema
 	data := schema.NewResourceData(&sch, target.Group.Params)
-	zkNode := data.Get("zookeeper").(string)
+	zkConnect := data.Get("zookeeper").(string)
+	if !strings.Contains(zkConnect, ":") {
+		// the name of the zookeeper cluster, use the default port
+		zkConnect = fmt.Sprintf("%s:2181", zkConnect)
+	}
 
 	target.Spec.AddEnv("KAFKA_BROKER_ID", strconv.Itoa(int(localIndex)))
-	target.Spec.AddEnv("KAFKA_ZOOKEEPER_CONNECT", fmt.Sprintf("%s:2181", zkNode))
-	target.Spec.AddEnv("KAFKA_ADVERTISED_LISTENERS", fmt.Sprintf("PLAINTEXT://%s:29092", target.FullName()))
+	target.Spec.AddEnv("KAFKA_ZOOKEEPER_CONNECT", zkConnect)
+	target.Spec.AddEnv("KAFKA_ADVERTISED_LISTENERS", fmt.Sprintf("PLAINTEXT://%s:%d", target.FullName(), brokerPort))
 	target.Spec.AddEnv("KAFKA_LISTENER_SECURITY_PROTOCOL_MAP", "PLAINTEXT:PLAINTEXT,PLAINTEXT_HOST:PLAINTEXT")
 	target.Spec.AddEnv("KAFKA_INTER_BROKER_LISTENER_NAME", "PLAINTEXT")
 	target.Spec.AddEnv("KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR", "1")
@@ -64,6 +70,8 @@ func (b *backend) Spec() *operator.Spec {
 				Schema: schema.Schema2{
 					Spec: &schema.Record{
 						Fields: map[string]*schema.Field{
+							// either the name of the zookeeper cluster or a reference
+							// to its connection string (${zookeeper.<name>.connect})
 							"zookeeper": {
 								Type:     schema.TypeString,
 								Required: true,
@@ -87,8 +95,11 @@ func (b *backend) Spec() *operator.Spec {
 			data := schema.NewResourceData(&sch, grp.Params)
 			zkNode := data.Get("zookeeper").(string)
 
-			spec.DependsOn = []string{
-				zkNode,
+			if !operator.IsReference(zkNode) {
+				// the referenced clusters are set as dependencies by the operator
+				spec.DependsOn = []string{
+					zkNode,
+				}
 			}
 			comp.Spec = proto.MustMarshalAny(&spec)
 			return comp, nil
@@ -97,9 +108,68 @@ func (b *backend) Spec() *operator.Spec {
 			"": func(spec *proto.NodeSpec, grp *proto.ClusterSpec_Group, data *schema.ResourceData) {
 			},
 		},
+		Outputs: map[string]*operator.Output{
+			"bootstrap": {
+				Type:        operator.OutputEndpoint,
+				Description: "Address of the kafka brokers",
+				Value: func(dep *proto.Deployment) (string, error) {
+					return operator.RunningEndpoints(dep, brokerPort), nil
+				},
+			},
+		},
+		Status: clusterStatus,
 	}
 }
 
+// clusterStatus returns the partitions of the topics that are under replicated
+func clusterStatus(ctx context.Context, req *operator.StatusRequest) (*proto.BackendStatus, error) {
+	broker := req.Running()[0]
+
+	out, err := req.Exec(ctx, broker, "kafka-topics",
+		"--bootstrap-server", fmt.Sprintf("%s:%d", broker.FullName(), brokerPort),
+		"--describe", "--under-replicated-partitions")
+	if err != nil {
+		return nil, err
+	}
+	partitions := underReplicatedPartitions(out)
+
+	status := &proto.BackendStatus{
+		Attributes: map[string]string{
+			"underReplicatedPartitions": strconv.Itoa(len(partitions)),
+		},
+	}
+	if len(partitions) != 0 {
+		status.Issues = append(status.Issues, fmt.Sprintf("under replicated partitions: %s", strings.Join(partitions, ", ")))
+	}
+	status.Healthy = len(status.Issues) == 0
+	return status, nil
+}
+
+// underReplicatedPartitions returns the partitions (as topic-partition) in
+// the output of 'kafka-topics --describe --under-replicated-partitions'
+func underReplicatedPartitions(out string) []string {
+	res := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		var topic, partition string
+		fields := strings.Fields(line)
+		for i := 0; i < len(fields)-1; i++ {
+			switch fields[i] {
+			case "Topic:":
+				topic = fields[i+1]
+			case "Partition:":
+				partition = fields[i+1]
+			}
+		}
+		if topic != "" && partition != "" {
+			res = append(res, topic+"-"+partition)
+		}
+	}
+	return res
+}
+
+// brokerPort is the port of the kafka brokers
+const brokerPort = 29092
+
 // Client implements the Handler interface
 func (b *backend) Client(node *proto.Instance) (interface{}, error) {
 	return nil, nil
@@ -3,7 +3,9 @@ package kafka
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/backends/zookeeper"
+	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/schema"
 	"github.com/teseraio/ensemble/testutil"
@@ -46,3 +48,59 @@ func TestE2E(t *testing.T) {
 
 	srv.WaitForTask(uuid)
 }
+
+func TestZookeeperReference(t *testing.T) {
+	h := operator.NewHarness(t)
+	h.Handler = Factory()
+	h.Scheduler = operator.NewScheduler(h)
+	h.Outputs = map[string]string{
+		"zookeeper.zk1.connect": "zk1-1:2181",
+	}
+
+	h.AddComponent(&proto.Component{
+		Id: "a1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{
+					Count: 1,
+					Params: schema.MapToSpec(map[string]interface{}{
+						"zookeeper": "${zookeeper.zk1.connect}",
+					}),
+				},
+			},
+		}),
+	})
+
+	plan := h.Eval()
+	h.Expect(plan, &operator.HarnessExpect{
+		Nodes: []*operator.HarnessExpectInstance{
+			{
+				Spec: &proto.NodeSpec{
+					Env: map[string]string{
+						"KAFKA_ZOOKEEPER_CONNECT": "zk1-1:2181",
+					},
+				},
+			},
+		},
+	})
+	h.ApplyDep(plan, func(n *proto.Instance) {
+		n.Status = proto.Instance_RUNNING
+		n.Healthy = true
+	})
+
+	// the output of the zookeeper cluster changes
+	h.Outputs["zookeeper.zk1.connect"] = "zk1-1:2181,zk1-2:2181"
+
+	plan = h.Eval()
+	if assert.Len(t, plan.NodeUpdate, 1) {
+		assert.Equal(t, plan.NodeUpdate[0].DesiredStatus, proto.Instance_STOP)
+	}
+}
+
+func TestUnderReplicatedPartitions(t *testing.T) {
+	out := "\tTopic: orders\tPartition: 0\tLeader: 1\tReplicas: 1,2\tIsr: 1\n" +
+		"\tTopic: orders\tPartition: 3\tLeader: 2\tReplicas: 2,1\tIsr: 2\n"
+
+	assert.Equal(t, underReplicatedPartitions(out), []string{"orders-0", "orders-3"})
+	assert.Empty(t, underReplicatedPartitions(""))
+}
@@ -1,7 +1,9 @@
 package rabbitmq
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
@@ -32,14 +34,14 @@ func Factory() operator.Handler {
 	return b
 }
 
-func (b *backend) startupProbe(instance *proto.Instance) error {
+func (b *backend) startupProbe(ctx context.Context, instance *proto.Instance) error {
 	clt, err := rabbithole.NewClient("http://"+instance.Ip+":15672", "guest", "guest")
 	if err != nil {
 		return err
 	}
 
 	// check if rabbimq is running
-	err = loopRetry(5*time.Minute, func() error {
+	err = loopRetry(ctx, func() error {
 		_, err = clt.Overview()
 		fmt.Println(err)
 		return err
@@ -51,7 +53,7 @@ func (b *backend) startupProbe(instance *proto.Instance) error {
 	nodesExpected, _ := instance.GetInt("num")
 
 	// check if its syncer with others
-	err = loopRetry(5*time.Minute, func() error {
+	err = loopRetry(ctx, func() error {
 		nodes, err := clt.ListNodes()
 		if err != nil {
 			return err
@@ -65,23 +67,16 @@ func (b *backend) startupProbe(instance *proto.Instance) error {
 	if err != nil {
 		return fmt.Errorf("failed cluster formation")
 	}
-
-	instance.Healthy = true
 	return nil
 }
 
-func loopRetry(timeout time.Duration, handler func() error) error {
-	doneCh := make(chan struct{})
-	go func() {
-		<-time.After(timeout)
-		doneCh <- struct{}{}
-	}()
-
+// loopRetry calls the handler until it succeeds or the context is done
+func loopRetry(ctx context.Context, handler func() error) error {
 	timeInterval := 1 * time.Second
 	for {
 		select {
 		case <-time.After(timeInterval):
-		case <-doneCh:
+		case <-ctx.Done():
 			return fmt.Errorf("timeout")
 		}
 
@@ -154,10 +149,110 @@ func (b *backend) Spec() *operator.Spec {
 			exchange(),
 			vhost(),
 		},
-		Startup: func(i *proto.Instance) error {
-			return b.startupProbe(i)
+		Startup: func(ctx context.Context, i *proto.Instance) error {
+			return b.startupProbe(ctx, i)
+		},
+		// the probe waits until the node joins the cluster
+		StartupProbe: &operator.Probe{
+			Timeout:  10 * time.Minute,
+			Attempts: 1,
 		},
+		HealthCheck: &operator.HealthCheck{
+			Client: nodeHealth,
+		},
+		Metrics: map[string]func(req *operator.MetricRequest) (float64, error){
+			"queue_depth": queueDepth,
+		},
+		Status: b.clusterStatus,
+	}
+}
+
+// clusterStatus returns the nodes of the cluster and the network partitions
+func (b *backend) clusterStatus(ctx context.Context, req *operator.StatusRequest) (*proto.BackendStatus, error) {
+	clt, err := req.Client(req.Running()[0])
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clt.(*rabbithole.Client).ListNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &proto.BackendStatus{}
+	for _, n := range nodes {
+		node := &proto.BackendStatus_Node{
+			Name:   n.Name,
+			Status: "running",
+		}
+		if !n.IsRunning {
+			node.Status = "stopped"
+			status.Issues = append(status.Issues, fmt.Sprintf("node %s is not running", n.Name))
+		}
+		if len(n.Partitions) != 0 {
+			node.Details = fmt.Sprintf("partitioned from %s", strings.Join(n.Partitions, ", "))
+			status.Issues = append(status.Issues, fmt.Sprintf("node %s is %s", n.Name, node.Details))
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+	status.Healthy = len(status.Issues) == 0
+	return status, nil
+}
+
+// nodeHealth checks that the node of the instance is running
+// and it is not partitioned from the rest of the cluster
+func nodeHealth(ctx context.Context, clt interface{}, i *proto.Instance) error {
+	client := clt.(*rabbithole.Client)
+
+	if err := setDeadline(ctx, client); err != nil {
+		return err
+	}
+	overview, err := client.Overview()
+	if err != nil {
+		return err
+	}
+	if err := setDeadline(ctx, client); err != nil {
+		return err
+	}
+	node, err := client.GetNode(overview.Node)
+	if err != nil {
+		return err
+	}
+	if !node.IsRunning {
+		return fmt.Errorf("node %s is not running", node.Name)
+	}
+	if len(node.Partitions) != 0 {
+		return fmt.Errorf("node %s is partitioned from %s", node.Name, strings.Join(node.Partitions, ", "))
+	}
+	return nil
+}
+
+// setDeadline bounds the next request of the client with the deadline of
+// the context since the requests of the client cannot be cancelled
+func setDeadline(ctx context.Context, client *rabbithole.Client) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		client.SetTimeout(time.Until(deadline))
+	}
+	return nil
+}
+
+// queueDepth returns the number of messages in all the queues of the cluster
+func queueDepth(req *operator.MetricRequest) (float64, error) {
+	clt, err := req.Client()
+	if err != nil {
+		return 0, err
+	}
+	queues, err := clt.(*rabbithole.Client).ListQueues()
+	if err != nil {
+		return 0, err
+	}
+	depth := 0
+	for _, q := range queues {
+		depth += q.Messages
 	}
+	return float64(depth), nil
 }
 
 // Client implements the Handler interface
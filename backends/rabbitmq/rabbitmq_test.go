@@ -1,8 +1,14 @@
 package rabbitmq
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	rabbithole "github.com/michaelklishin/rabbit-hole/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/testutil"
 )
@@ -42,3 +48,23 @@ func TestE2E(t *testing.T) {
 
 	srv.WaitForTask(uuid)
 }
+
+func TestNodeHealth_Deadline(t *testing.T) {
+	// the management api does not respond
+	doneCh := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-doneCh
+	}))
+	defer srv.Close()
+	defer close(doneCh)
+
+	clt, err := rabbithole.NewClient(srv.URL, "guest", "guest")
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.Error(t, nodeHealth(ctx, clt, &proto.Instance{}))
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
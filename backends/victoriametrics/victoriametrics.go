@@ -1,7 +1,13 @@
 package victoriametrics
 
 import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	gproto "github.com/golang/protobuf/proto"
 	"github.com/teseraio/ensemble/operator"
@@ -67,6 +73,7 @@ func (b *backend) Spec() *operator.Spec {
 				DefaultVersion: "latest",
 				Volumes:        []*operator.Volume{},
 				Ports:          []*operator.Port{},
+				DependsOn:      []string{"storage"},
 				Schema: schema.Schema2{
 					Spec: &schema.Record{
 						Fields: map[string]*schema.Field{
@@ -83,6 +90,7 @@ func (b *backend) Spec() *operator.Spec {
 				DefaultVersion: "latest",
 				Volumes:        []*operator.Volume{},
 				Ports:          []*operator.Port{},
+				DependsOn:      []string{"storage"},
 				Schema: schema.Schema2{
 					Spec: &schema.Record{
 						Fields: map[string]*schema.Field{
@@ -123,7 +131,100 @@ func (b *backend) Spec() *operator.Spec {
 			"": func(spec *proto.NodeSpec, grp *proto.ClusterSpec_Group, data *schema.ResourceData) {
 			},
 		},
+		Metrics: map[string]func(req *operator.MetricRequest) (float64, error){
+			"ingestion_rate": ingestionRate,
+		},
+	}
+}
+
+// ingestionSampleInterval is the time between the two samples
+// of the counters used to compute the ingestion rate
+var ingestionSampleInterval = 5 * time.Second
+
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+}
+
+// ingestionRate returns the number of rows per second inserted in the
+// cluster through all the insert nodes
+func ingestionRate(req *operator.MetricRequest) (float64, error) {
+	nodes := req.Healthy("insert")
+	if len(nodes) == 0 {
+		return 0, fmt.Errorf("no healthy insert nodes found")
+	}
+
+	sample := func() (float64, error) {
+		total := float64(0)
+		for _, n := range nodes {
+			rows, err := readCounter("http://"+n.Ip+":8480/metrics", "vm_rows_inserted_total")
+			if err != nil {
+				return 0, err
+			}
+			total += rows
+		}
+		return total, nil
+	}
+
+	start := time.Now()
+	first, err := sample()
+	if err != nil {
+		return 0, err
+	}
+	time.Sleep(ingestionSampleInterval)
+	second, err := sample()
+	if err != nil {
+		return 0, err
+	}
+
+	rate := (second - first) / time.Since(start).Seconds()
+	if rate < 0 {
+		// the counters were reset by a restart
+		return 0, nil
+	}
+	return rate, nil
+}
+
+// readCounter returns the value of a counter in the metrics endpoint of a node
+func readCounter(url string, name string) (float64, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return parseCounter(resp.Body, name)
+}
+
+// parseCounter returns the sum of all the series of a counter
+// in the Prometheus text format
+func parseCounter(r io.Reader, name string) (float64, error) {
+	total := float64(0)
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, name+"{") && !strings.HasPrefix(line, name+" ") {
+			continue
+		}
+		fields := strings.Fields(line)
+		value, err := strconv.ParseFloat(fields[len(fields)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse '%s': %v", line, err)
+		}
+		total += value
+		found = true
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("counter '%s' not found", name)
 	}
+	return total, nil
 }
 
 // Client implements the Handler interface
@@ -1,12 +1,29 @@
 package victoriametrics
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/testutil"
 )
 
+func TestParseCounter(t *testing.T) {
+	metrics := `# HELP vm_rows_inserted_total
+vm_rows_inserted_total{type="influx"} 10
+vm_rows_inserted_total{type="promremotewrite"} 25.5
+vm_rows_inserted_totals 100
+vm_http_requests_total{path="/insert"} 7
+`
+	total, err := parseCounter(strings.NewReader(metrics), "vm_rows_inserted_total")
+	assert.NoError(t, err)
+	assert.Equal(t, total, 35.5)
+
+	_, err = parseCounter(strings.NewReader(metrics), "vm_rows_added_to_storage_total")
+	assert.Error(t, err)
+}
+
 func TestE2E(t *testing.T) {
 	// testutil.IsE2EEnabled(t)
 
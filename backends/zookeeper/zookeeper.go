@@ -1,6 +1,7 @@
 package zookeeper
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -108,9 +109,73 @@ func (b *backend) Spec() *operator.Spec {
 				spec.AddEnv("ZOO_TICK_TIME", data.Get("tickTime").(string))
 			},
 		},
+		Outputs: map[string]*operator.Output{
+			"connect": {
+				Type:        operator.OutputEndpoint,
+				Description: "Connection string with the address of the zookeeper nodes",
+				Value: func(dep *proto.Deployment) (string, error) {
+					return operator.RunningEndpoints(dep, clientPort), nil
+				},
+			},
+			"port": {
+				Type:        operator.OutputPort,
+				Description: "Port of the zookeeper clients",
+				Value: func(dep *proto.Deployment) (string, error) {
+					return strconv.Itoa(clientPort), nil
+				},
+			},
+		},
+		Status: clusterStatus,
+	}
+}
+
+// clusterStatus returns the mode of each zookeeper node in the ensemble
+func clusterStatus(ctx context.Context, req *operator.StatusRequest) (*proto.BackendStatus, error) {
+	status := &proto.BackendStatus{
+		Attributes: map[string]string{},
+	}
+
+	leaders := []string{}
+	for _, i := range req.Running() {
+		node := &proto.BackendStatus_Node{
+			Name: i.Name,
+		}
+		out, err := req.Exec(ctx, i, "zkServer.sh", "status")
+		if err != nil {
+			node.Status = "unknown"
+			node.Details = err.Error()
+			status.Issues = append(status.Issues, fmt.Sprintf("node %s is not serving requests", i.Name))
+		} else {
+			node.Status = parseMode(out)
+			if node.Status == "leader" || node.Status == "standalone" {
+				leaders = append(leaders, i.Name)
+			}
+		}
+		status.Nodes = append(status.Nodes, node)
+	}
+
+	if len(leaders) == 0 {
+		status.Issues = append(status.Issues, "there is no leader in the ensemble")
+	} else {
+		status.Attributes["leader"] = strings.Join(leaders, ", ")
 	}
+	status.Healthy = len(status.Issues) == 0
+	return status, nil
 }
 
+// parseMode returns the mode of the node in the output of 'zkServer.sh status'
+func parseMode(out string) string {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "Mode:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "Mode:"))
+		}
+	}
+	return "unknown"
+}
+
+// clientPort is the port of the zookeeper clients
+const clientPort = 2181
+
 // Client implements the Handler interface
 func (b *backend) Client(node *proto.Instance) (interface{}, error) {
 	return nil, nil
@@ -3,6 +3,7 @@ package zookeeper
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/schema"
@@ -98,6 +99,16 @@ func TestBootstrap(t *testing.T) {
 	})
 }
 
+func TestParseMode(t *testing.T) {
+	out := `ZooKeeper JMX enabled by default
+Using config: /conf/zoo.cfg
+Client port found: 2181. Client address: localhost. Client SSL: false.
+Mode: follower
+`
+	assert.Equal(t, parseMode(out), "follower")
+	assert.Equal(t, parseMode("Error contacting service. It is probably not running."), "unknown")
+}
+
 func TestE2E(t *testing.T) {
 	testutil.IsE2EEnabled(t)
 
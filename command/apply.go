@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/teseraio/ensemble/command/flagset"
 	"github.com/teseraio/ensemble/k8s"
@@ -10,8 +11,9 @@ import (
 )
 
 type ApplyCommand struct {
-	filename  string
-	recursive bool
+	filename         string
+	recursive        bool
+	expectedSequence int
 
 	Meta
 }
@@ -39,6 +41,10 @@ func (c *ApplyCommand) Help() string {
 
     $ cat pod.yaml | ensemble apply -f -
 
+  Apply a configuration only if the latest version of the resource is 3:
+
+    $ ensemble apply -expected-sequence 3 -f pod.yaml
+
 ` + c.Flags().Help()
 }
 
@@ -57,6 +63,12 @@ func (c *ApplyCommand) Flags() *flagset.Flagset {
 		Usage: "Process the directory used in -f, --filename recursively",
 	})
 
+	f.IntFlag(&flagset.IntFlag{
+		Name:  "expected-sequence",
+		Value: &c.expectedSequence,
+		Usage: "Fail if the latest version of the resource is not this one",
+	})
+
 	return f
 }
 
@@ -87,16 +99,23 @@ func (c *ApplyCommand) Run(args []string) int {
 		return 1
 	}
 	comp.Action = proto.Component_CREATE
+	comp.ExpectedSequence = int64(c.expectedSequence)
 
 	clt, err := c.Conn()
 	if err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
-	if _, err := clt.Apply(context.Background(), comp); err != nil {
+	resp, err := clt.Apply(context.Background(), comp)
+	if err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
+	if resp.Sequence == 0 {
+		c.UI.Output(fmt.Sprintf("Resource %s unchanged", comp.Name))
+	} else {
+		c.UI.Output(fmt.Sprintf("Resource %s applied with sequence %d", comp.Name, resp.Sequence))
+	}
 	return 0
 }
 
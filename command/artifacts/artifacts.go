@@ -128,7 +128,7 @@ func ChartsOperatorChartYaml() (*asset, error) {
 	return a, nil
 }
 
-var _ChartsOperatorCrdsClusterYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x54\x4d\xaf\xd3\x30\x10\xbc\xe7\x57\xec\x1f\x48\xd1\x13\x17\x94\x1b\x02\x09\x71\x41\x4f\x20\xbd\xfb\x26\x1e\x82\x69\x62\x9b\x5d\xbb\xe2\x09\xf1\xdf\x91\xf3\xd1\x96\xd6\x6d\x10\xb0\xb7\x8c\xc7\x93\x99\xb5\xbd\x75\x5d\x57\x1c\xec\x13\x44\xad\x77\x0d\x71\xb0\xf8\x1e\xe1\xf2\x97\xee\xf6\xaf\x74\x67\xfd\x8b\xc3\x43\xb5\xb7\xce\x34\xf4\x26\x69\xf4\xe3\x47\xa8\x4f\xd2\xe1\x2d\x3e\x5b\x67\xa3\xf5\xae\x1a\x11\xd9\x70\xe4\xa6\x22\x72\x3c\xa2\xa1\x6e\x48\x1a\x21\xba\x83\x53\x8c\xed\x00\xaf\x59\xab\xd2\x80\x2e\xb3\x7a\xf1\x29\x34\x74\xb1\x4a\x74\x98\x9d\x68\xe6\xd4\x8b\xd6\xe1\xa1\x22\x22\x52\xc8\x01\xa6\xa1\x28\x09\x33\x10\xbd\x70\x8f\x73\xa4\xfb\x82\x71\x72\x91\xcb\x07\xb8\xd7\x8f\xef\x9f\x5e\x7e\xfa\x0d\x26\x8a\xcf\x01\x0d\xf9\xf6\x2b\xba\x78\x04\x83\xf8\x00\x89\x16\x7a\x22\x12\xad\x7e\x4f\x55\xdc\x7c\x5b\x20\x57\xcb\xdd\x1e\xce\x5c\xc2\x77\xb4\xee\xeb\xe5\x9a\x5a\x53\xc0\x57\x51\x8d\x62\x5d\x7f\x45\x10\x7c\x4b\x56\x50\xf0\x32\x77\xfb\x02\x9e\x8e\xa9\xf0\xff\xf9\x1f\x2c\xc2\xcf\x57\x6b\x36\x62\x2c\x5a\xbe\x9b\x76\x2b\xef\xbd\xc4\x1b\x99\x8f\x84\xbf\xdd\x2c\x08\x83\xed\xf8\x86\xaf\x55\xc0\xba\x88\x1e\x52\xe4\x04\x16\x2e\x37\x85\xb6\x1b\x93\x8b\x8d\x99\x9e\x1a\x0f\x8f\x1b\x6d\xfa\x83\x44\xb7\x2f\x41\xbe\x06\x6b\xda\x8b\x45\x83\x00\x67\xfe\xef\x5d\x28\x38\x2c\x7b\xab\xd7\x37\x74\xfe\x34\x23\xc7\xa4\xff\xfc\x38\x7d\x3b\x0f\x96\x77\x70\x10\xce\x3d\xbe\x15\xf1\xfc\x80\x35\xb5\xb2\xcc\xc1\xa3\xe2\x62\x88\x7e\xfc\xac\xf2\x28\xf2\x79\xcf\x07\x1e\xa1\x81\x3b\x98\x65\x34\x2e\xec\x30\x24\xe1\xe1\x34\x27\x67\x51\xeb\xfa\x34\xb0\x1c\xe1\x09\x5d\x66\xef\x8c\xfc\x0a\x00\x00\xff\xff\x00\x0a\x76\x39\xb1\x05\x00\x00")
+var _ChartsOperatorCrdsClusterYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\xcd\x6e\xdb\x30\x0c\xbe\xfb\x29\xf8\x02\xce\x50\xec\x32\xf8\x56\xac\xc0\xb0\xcb\x56\xac\x58\xef\xb4\xc4\x79\x5a\x64\x4a\x23\xa5\x2c\xc5\xb0\x77\x1f\xfc\x93\xb4\x4b\xfd\x93\x2e\x85\x73\xc9\x47\xea\x33\xf9\x91\xa2\x59\x96\x65\x81\xd1\xdd\x93\xa8\x0b\x5c\x01\x46\x47\xfb\x44\xdc\xfd\xd3\xcd\xf6\x9d\x6e\x5c\x78\xb3\xbb\x2a\xb6\x8e\x6d\x05\xef\xb3\xa6\xd0\x7e\x21\x0d\x59\x0c\xdd\xd0\x37\xc7\x2e\xb9\xc0\x45\x4b\x09\x2d\x26\xac\x0a\x00\xc6\x96\x2a\x30\x3e\x6b\x22\xd1\x0d\xb1\x52\x5b\x7b\x0a\xda\x71\x15\x1a\xc9\x74\x5e\x8d\x84\x1c\x2b\x38\xb1\x02\xec\x86\x48\xb4\xf3\x29\x47\xae\xdd\x55\x01\x00\xa0\x24\x3b\xb2\x15\x24\xc9\x34\x00\x29\x08\x36\xf4\x14\x31\xdf\xa9\xed\xa3\xe8\xec\x21\x12\x5f\xdf\x7e\xbc\x7f\x7b\xf7\x0f\x0c\x90\x1e\x22\x55\x10\xea\x1f\x64\xd2\x11\x8c\x12\x22\x49\x72\xa4\x8f\x8e\x00\x87\x78\x01\x16\x0f\xcf\x13\x74\x4f\x8d\x66\x4b\x6c\x4f\xe1\x05\xae\x65\xbe\xee\xe9\xa5\x99\xc0\x0f\xa4\x9a\xc4\x71\xf3\xcc\x41\xe8\x67\x76\x42\x13\xb1\x0c\x6a\x9f\xc0\x7d\x99\x26\xde\x3f\xbc\x03\x45\xf0\xe1\x99\xcd\x25\x6a\x27\x43\x5e\xcc\x76\x2d\xdf\xa5\x8c\x57\x72\x3e\x3a\xfc\xef\x61\xa1\xe8\x9d\x41\x5d\x26\x70\x9c\xa8\x21\x99\xf4\x69\x1d\x5f\xef\xd0\x79\xac\x3d\x5d\xc0\x82\xfb\xaf\x8c\x97\xf3\x58\x8a\xc4\x56\x3f\xf3\x32\xc5\x74\x79\x57\x8a\x7c\xa6\xa4\x98\x53\x50\x83\x6b\x59\xcc\x36\xcb\x39\x0d\x33\x0a\x3f\x6f\x3c\x47\xab\x51\xf7\x57\x20\xa1\x24\xee\x64\x96\xbc\x50\xb4\xee\x97\x50\x1a\x4a\xeb\x34\x9c\xdb\x7a\x21\x1a\x13\x82\xb7\xe1\x17\xdf\x91\x09\x6c\x75\x9d\x6f\x39\xbd\xf9\xb1\x72\x18\x2e\xad\xe3\x79\x1b\xee\xe7\x6d\xbd\x6c\xb3\xe6\x41\x8e\x49\x73\x44\xc1\x56\x2f\xe8\x2f\xb4\xb6\xff\xbc\xa1\xbf\x3d\xa3\xd3\x56\xaa\xb7\xa4\x50\x79\x9c\x30\xc5\xe4\x45\xad\x8a\x97\x5c\xd0\x95\xf9\x3b\x19\x61\x94\xd0\x08\xa9\xde\x10\x5a\xef\x98\x66\xdb\x62\xbe\x19\xa6\x13\x2c\x0f\x1f\xbf\x27\xa8\x26\x4c\xf9\x84\x7b\xb6\x1c\xf3\x97\x3c\xd4\xc3\x46\xf0\x81\x98\x04\xbb\x42\x9d\x13\xae\xe6\x5a\xc6\x05\xe6\xc8\x38\x06\x04\xbf\xff\x14\x00\x6a\x42\x77\xe6\x13\xb6\xa4\x11\x0d\xd9\x71\xa7\x19\xbd\xa3\xcf\x82\xfe\x71\xc1\xe9\x41\x75\xdc\x64\x8f\x72\x84\x7b\x74\x5c\x9a\x7c\xd6\x44\xf2\x77\x00\x4e\x38\x3d\xc4\x6a\x09\x00\x00")
 
 func ChartsOperatorCrdsClusterYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -143,7 +143,7 @@ func ChartsOperatorCrdsClusterYaml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "../charts/operator/crds/cluster.yaml", size: 1457, mode: os.FileMode(436), modTime: time.Unix(1627669519, 0)}
+	info := bindataFileInfo{name: "../charts/operator/crds/cluster.yaml", size: 2410, mode: os.FileMode(436), modTime: time.Unix(1792425566, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -57,6 +57,73 @@ func Commands() map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"deployment restart": func() (cli.Command, error) {
+			return &DeploymentRestartCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment scale": func() (cli.Command, error) {
+			return &DeploymentScaleCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment pause": func() (cli.Command, error) {
+			return &DeploymentPauseCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment resume": func() (cli.Command, error) {
+			return &DeploymentResumeCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment purge": func() (cli.Command, error) {
+			return &DeploymentPurgeCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment graph": func() (cli.Command, error) {
+			return &DeploymentGraphCommand{
+				Meta: meta,
+			}, nil
+		},
+		"eval": func() (cli.Command, error) {
+			return &EvalCommand{}, nil
+		},
+		"eval list": func() (cli.Command, error) {
+			return &EvalListCommand{
+				Meta: meta,
+			}, nil
+		},
+		"eval status": func() (cli.Command, error) {
+			return &EvalStatusCommand{
+				Meta: meta,
+			}, nil
+		},
+		"instance": func() (cli.Command, error) {
+			return &InstanceCommand{}, nil
+		},
+		"instance replace": func() (cli.Command, error) {
+			return &InstanceReplaceCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator": func() (cli.Command, error) {
+			return &OperatorCommand{}, nil
+		},
+		"operator snapshot": func() (cli.Command, error) {
+			return &OperatorSnapshotCommand{}, nil
+		},
+		"operator snapshot save": func() (cli.Command, error) {
+			return &OperatorSnapshotSaveCommand{
+				Meta: meta,
+			}, nil
+		},
+		"operator snapshot restore": func() (cli.Command, error) {
+			return &OperatorSnapshotRestoreCommand{
+				Meta: meta,
+			}, nil
+		},
 		"k8s": func() (cli.Command, error) {
 			return &K8sCommand{}, nil
 		},
@@ -12,6 +12,7 @@ type DeleteCommand struct {
 
 	filename  string
 	recursive bool
+	cascade   bool
 }
 
 // Synopsis implements the cli.Command interface
@@ -37,6 +38,11 @@ func (d *DeleteCommand) Help() string {
 
     $ cat pod.yaml | ensemble delete -f -
 
+  A cluster that other clusters depend on is only deleted with -cascade,
+  which deletes the dependent clusters first:
+
+    $ ensemble delete -cascade -f zookeeper.yaml
+
 ` + d.Flags().Help()
 }
 
@@ -55,6 +61,12 @@ func (d *DeleteCommand) Flags() *flagset.Flagset {
 		Usage: "Process the directory used in -f, --filename recursively",
 	})
 
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:  "cascade",
+		Value: &d.cascade,
+		Usage: "Delete the clusters that depend on the cluster too",
+	})
+
 	return f
 }
 
@@ -78,6 +90,7 @@ func (d *DeleteCommand) Run(args []string) int {
 		return 1
 	}
 	comp.Action = proto.Component_DELETE
+	comp.Cascade = d.cascade
 
 	clt, err := d.Conn()
 	if err != nil {
@@ -20,7 +20,20 @@ func (c *DeploymentCommand) Help() string {
   
   Display the status of a specific deployment:
 
-    $ ensemble deployment status <deployment_id>`
+    $ ensemble deployment status <deployment_id>
+
+  Change the number of instances of a deployment:
+
+    $ ensemble deployment scale <deployment_id> <count>
+
+  Perform a rolling restart of a deployment:
+
+    $ ensemble deployment restart <deployment_id>
+
+  Pause and resume the reconciliation of a deployment:
+
+    $ ensemble deployment pause <deployment_id>
+    $ ensemble deployment resume <deployment_id>`
 }
 
 // Synopsis implements the cli.Command interface
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type DeploymentGraphCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentGraphCommand) Help() string {
+	return `Usage: ensemble deployment graph
+
+  Display the dependencies between the deployments. Each deployment is listed
+  under the deployments it depends on.
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentGraphCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("deployment graph")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentGraphCommand) Synopsis() string {
+	return "Display the dependencies between the deployments"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentGraphCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	graph, err := clt.GetDependencyGraph(context.Background(), &emptypb.Empty{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Output(formatDependencyGraph(graph))
+	return 0
+}
+
+func formatDependencyGraph(graph *proto.DependencyGraph) string {
+	if len(graph.Nodes) == 0 {
+		return "No deployments found"
+	}
+
+	nodes := map[string]*proto.DependencyGraph_Node{}
+	for _, node := range graph.Nodes {
+		nodes[node.Id] = node
+	}
+
+	// the roots are the deployments without any dependency
+	roots := []*proto.DependencyGraph_Node{}
+	dependents := map[string][]*proto.DependencyGraph_Node{}
+	for _, node := range graph.Nodes {
+		isRoot := true
+		for _, id := range node.DependsOn {
+			if _, ok := nodes[id]; ok {
+				dependents[id] = append(dependents[id], node)
+				isRoot = false
+			}
+		}
+		if isRoot {
+			roots = append(roots, node)
+		}
+	}
+	sortNodes(roots)
+
+	var b strings.Builder
+	var visit func(node *proto.DependencyGraph_Node, depth int, path map[string]struct{})
+	visit = func(node *proto.DependencyGraph_Node, depth int, path map[string]struct{}) {
+		line := fmt.Sprintf("%s%s (%s)", strings.Repeat("  ", depth), node.Name, node.Id)
+		if node.Deleted {
+			line += " [deleted]"
+		}
+		b.WriteString(line + "\n")
+
+		if _, ok := path[node.Id]; ok {
+			return
+		}
+		path[node.Id] = struct{}{}
+		defer delete(path, node.Id)
+
+		children := dependents[node.Id]
+		sortNodes(children)
+		for _, child := range children {
+			visit(child, depth+1, path)
+		}
+	}
+	for _, root := range roots {
+		visit(root, 0, map[string]struct{}{})
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func sortNodes(nodes []*proto.DependencyGraph_Node) {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Name != nodes[j].Name {
+			return nodes[i].Name < nodes[j].Name
+		}
+		return nodes[i].Id < nodes[j].Id
+	})
+}
@@ -0,0 +1,63 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type DeploymentPauseCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentPauseCommand) Help() string {
+	return `Usage: ensemble deployment pause <id>
+
+  Pause the reconciliation of a deployment. The instances are not modified
+  and any new configuration is kept pending until the deployment is resumed.
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentPauseCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("deployment pause")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentPauseCommand) Synopsis() string {
+	return "Pause the reconciliation of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentPauseCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <id> expected")
+		return 1
+	}
+	depID := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dep, err := clt.Pause(context.Background(), &proto.PauseReq{Cluster: depID})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Deployment %s paused", dep.Id))
+	return 0
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type DeploymentPurgeCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentPurgeCommand) Help() string {
+	return `Usage: ensemble deployment purge <id>
+
+  Remove a deleted deployment and all its history from the state. Only the
+  deployments whose deletion is complete can be purged.
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentPurgeCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("deployment purge")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentPurgeCommand) Synopsis() string {
+	return "Remove a deleted deployment and its history"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentPurgeCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <id> expected")
+		return 1
+	}
+	depID := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if _, err := clt.Purge(context.Background(), &proto.PurgeReq{Cluster: depID}); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Deployment %s purged", depID))
+	return 0
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type DeploymentRestartCommand struct {
+	Meta
+
+	group string
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentRestartCommand) Help() string {
+	return `Usage: ensemble deployment restart [options] <id>
+
+  Perform a rolling restart of the instances of a deployment.
+
+  Restart all the instances of the deployment:
+
+    $ ensemble deployment restart <deployment_id>
+
+  Restart only the instances of a group:
+
+    $ ensemble deployment restart -group worker <deployment_id>
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentRestartCommand) Flags() *flagset.Flagset {
+	f := c.NewFlagSet("deployment restart")
+
+	f.StringFlag(&flagset.StringFlag{
+		Name:  "group",
+		Value: &c.group,
+		Usage: "Restart only the instances of this group",
+	})
+
+	return f
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentRestartCommand) Synopsis() string {
+	return "Perform a rolling restart of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentRestartCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <id> expected")
+		return 1
+	}
+	depID := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dep, err := clt.Restart(context.Background(), &proto.RestartReq{Cluster: depID, Group: c.group})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Restart of deployment %s started", dep.Id))
+	return 0
+}
@@ -0,0 +1,62 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type DeploymentResumeCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentResumeCommand) Help() string {
+	return `Usage: ensemble deployment resume <id>
+
+  Resume the reconciliation of a paused deployment.
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentResumeCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("deployment resume")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentResumeCommand) Synopsis() string {
+	return "Resume the reconciliation of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentResumeCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <id> expected")
+		return 1
+	}
+	depID := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	dep, err := clt.Resume(context.Background(), &proto.ResumeReq{Cluster: depID})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Deployment %s resumed", dep.Id))
+	return 0
+}
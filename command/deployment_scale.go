@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type DeploymentScaleCommand struct {
+	Meta
+
+	group string
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentScaleCommand) Help() string {
+	return `Usage: ensemble deployment scale [options] <id> <count>
+
+  Change the number of instances of a group in the deployment.
+
+  Scale a deployment with a single group:
+
+    $ ensemble deployment scale <deployment_id> 5
+
+  Scale a specific group. The group is required if the deployment
+  has more than one:
+
+    $ ensemble deployment scale -group worker <deployment_id> 5
+
+` + c.Flags().Help()
+}
+
+func (c *DeploymentScaleCommand) Flags() *flagset.Flagset {
+	f := c.NewFlagSet("deployment scale")
+
+	f.StringFlag(&flagset.StringFlag{
+		Name:  "group",
+		Value: &c.group,
+		Usage: "Type of the group to scale. It can be omitted if the deployment has only one group",
+	})
+
+	return f
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentScaleCommand) Synopsis() string {
+	return "Change the number of instances of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentScaleCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 2 {
+		c.UI.Error("arguments <id> and <count> expected")
+		return 1
+	}
+	depID := args[0]
+
+	count, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("failed to parse count: %v", err))
+		return 1
+	}
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	comp, err := clt.Scale(context.Background(), &proto.ScaleReq{Cluster: depID, Group: c.group, Count: count})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if comp.Id == "" {
+		c.UI.Output("No changes applied")
+		return 0
+	}
+
+	c.UI.Output(fmt.Sprintf("Deployment %s scaled (version %d)", depID, comp.Sequence))
+	return 0
+}
@@ -3,7 +3,9 @@ package command
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/teseraio/ensemble/command/flagset"
 	"github.com/teseraio/ensemble/operator/proto"
 )
@@ -69,12 +71,44 @@ func formatDeployment(dep *proto.Deployment) string {
 		fmt.Sprintf("Version|%d", dep.Sequence),
 		fmt.Sprintf("Status|%s", dep.Status),
 	})
+	if dep.Paused {
+		base += "\n" + formatKV([]string{
+			"Paused|true",
+		})
+	}
+	if dep.Reason != "" {
+		base += "\n" + formatKV([]string{
+			fmt.Sprintf("Reason|%s", dep.Reason),
+		})
+	}
+
+	if len(dep.Conditions) != 0 {
+		rows := make([]string, len(dep.Conditions)+1)
+		rows[0] = "Condition|Since|Message"
+		for i, c := range dep.Conditions {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s",
+				c.Type,
+				ptypes.TimestampString(c.Timestamp),
+				c.Message,
+			)
+		}
+		base += "\n\n" + formatList(rows)
+	}
+
+	if len(dep.Blocked) != 0 {
+		base += "\n\n" + formatBlocked(dep.Blocked)
+	}
+
+	if dep.BackendStatus != nil {
+		base += "\n\nBackend\n" + formatBackendStatus(dep.BackendStatus)
+	}
 
 	if len(dep.Instances) != 0 {
 		rows := make([]string, len(dep.Instances)+1)
-		rows[0] = "Name|Healthy|Status"
+		rows[0] = "ID|Name|Healthy|Status"
 		for i, d := range dep.Instances {
-			rows[i+1] = fmt.Sprintf("%s|%v|%s",
+			rows[i+1] = fmt.Sprintf("%s|%s|%v|%s",
+				d.ID,
 				d.Name,
 				d.Healthy,
 				d.Status,
@@ -84,3 +118,55 @@ func formatDeployment(dep *proto.Deployment) string {
 	}
 	return base
 }
+
+// formatBlocked returns the reasons why a deployment has not converged yet
+func formatBlocked(blocked []*proto.Deployment_BlockedReason) string {
+	rows := make([]string, len(blocked)+1)
+	rows[0] = "Group|Blocked"
+	for i, b := range blocked {
+		rows[i+1] = fmt.Sprintf("%s|%s",
+			b.Group,
+			b.Message,
+		)
+	}
+	return formatList(rows)
+}
+
+// formatBackendStatus returns the status of the cluster reported by the backend
+func formatBackendStatus(status *proto.BackendStatus) string {
+	if status.Error != "" {
+		return formatKV([]string{
+			fmt.Sprintf("Error|%s", status.Error),
+		})
+	}
+
+	rows := []string{
+		fmt.Sprintf("Healthy|%v", status.Healthy),
+	}
+	keys := []string{}
+	for k := range status.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		rows = append(rows, fmt.Sprintf("%s|%s", k, status.Attributes[k]))
+	}
+	for _, issue := range status.Issues {
+		rows = append(rows, fmt.Sprintf("Issue|%s", issue))
+	}
+	base := formatKV(rows)
+
+	if len(status.Nodes) != 0 {
+		rows := make([]string, len(status.Nodes)+1)
+		rows[0] = "Node|Status|Details"
+		for i, n := range status.Nodes {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s",
+				n.Name,
+				n.Status,
+				n.Details,
+			)
+		}
+		base += "\n\n" + formatList(rows)
+	}
+	return base
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+type EvalCommand struct {
+	UI cli.Ui
+}
+
+// Help implements the cli.Command interface
+func (c *EvalCommand) Help() string {
+	return `Usage: ensemble eval <subcommand>
+
+  This command groups actions to inspect the evaluations of the scheduler.
+
+  List the evaluations of a deployment:
+
+    $ ensemble eval list <deployment_id>
+
+  Display the status and the plan of an evaluation:
+
+    $ ensemble eval status <eval_id>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *EvalCommand) Synopsis() string {
+	return "Inspect the evaluations of the scheduler"
+}
+
+// Run implements the cli.Command interface
+func (c *EvalCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type EvalListCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *EvalListCommand) Help() string {
+	return `Usage: ensemble eval list [deployment_id]
+
+  List the evaluations of a deployment sorted by creation time. If no
+  deployment is given, it lists the evaluations of all the deployments.
+
+` + c.Flags().Help()
+}
+
+func (c *EvalListCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("eval list")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *EvalListCommand) Synopsis() string {
+	return "List the evaluations of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *EvalListCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("at most one argument <deployment_id> expected")
+		return 1
+	}
+	var depID string
+	if len(args) == 1 {
+		depID = args[0]
+	}
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := clt.ListEvaluations(context.Background(), &proto.ListEvaluationsReq{Cluster: depID})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Output(formatEvaluations(resp.Evaluations))
+	return 0
+}
+
+func formatEvaluations(evals []*proto.Evaluation) string {
+	if len(evals) == 0 {
+		return "No evaluations found"
+	}
+
+	rows := make([]string, len(evals)+1)
+	rows[0] = "ID|Deployment|Trigger|Status|Priority|Created|Changes"
+	for i, e := range evals {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%d|%s|%s",
+			e.Id,
+			e.DeploymentID,
+			e.TriggeredBy,
+			e.Status,
+			e.Priority,
+			formatTimestamp(e.CreatedAt),
+			formatPlanSummary(e.Plan),
+		)
+	}
+	return formatList(rows)
+}
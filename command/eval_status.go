@@ -0,0 +1,177 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type EvalStatusCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *EvalStatusCommand) Help() string {
+	return `Usage: ensemble eval status <eval_id>
+
+  Display the status of an evaluation and the plan it computed. The
+  id can be a unique prefix of the id of the evaluation.
+
+` + c.Flags().Help()
+}
+
+func (c *EvalStatusCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("eval status")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *EvalStatusCommand) Synopsis() string {
+	return "Display the status of an evaluation"
+}
+
+// Run implements the cli.Command interface
+func (c *EvalStatusCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <eval_id> expected")
+		return 1
+	}
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	eval, err := clt.GetEvaluation(context.Background(), &proto.GetEvaluationReq{Id: args[0]})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	c.UI.Output(formatEvaluation(eval))
+	return 0
+}
+
+func formatEvaluation(eval *proto.Evaluation) string {
+	rows := []string{
+		fmt.Sprintf("ID|%s", eval.Id),
+		fmt.Sprintf("Deployment|%s", eval.DeploymentID),
+		fmt.Sprintf("Type|%s", eval.Type),
+		fmt.Sprintf("Trigger|%s", eval.TriggeredBy),
+		fmt.Sprintf("Status|%s", eval.Status),
+		fmt.Sprintf("Priority|%d", eval.Priority),
+		fmt.Sprintf("Coalesced|%d", eval.Coalesced),
+		fmt.Sprintf("Created|%s", formatTimestamp(eval.CreatedAt)),
+		fmt.Sprintf("Started|%s", formatTimestamp(eval.StartedAt)),
+		fmt.Sprintf("Completed|%s", formatTimestamp(eval.CompletedAt)),
+	}
+	if eval.StartedAt != nil && eval.CompletedAt != nil {
+		start, err1 := ptypes.Timestamp(eval.StartedAt)
+		end, err2 := ptypes.Timestamp(eval.CompletedAt)
+		if err1 == nil && err2 == nil {
+			rows = append(rows, fmt.Sprintf("Duration|%s", end.Sub(start)))
+		}
+	}
+	if eval.StatusDescription != "" {
+		rows = append(rows, fmt.Sprintf("Description|%s", eval.StatusDescription))
+	}
+	if eval.Error != "" {
+		rows = append(rows, fmt.Sprintf("Error|%s", eval.Error))
+	}
+	base := formatKV(rows)
+
+	plan := eval.Plan
+	if plan == nil {
+		return base
+	}
+
+	planRows := []string{
+		fmt.Sprintf("Status|%s", plan.Status),
+		fmt.Sprintf("Done|%v", plan.Done),
+	}
+	if plan.Reason != "" {
+		planRows = append(planRows, fmt.Sprintf("Reason|%s", plan.Reason))
+	}
+	if plan.Stale {
+		planRows = append(planRows, "Stale|true")
+	}
+	base += "\n\nPlan\n" + formatKV(planRows)
+
+	if len(plan.Blocked) != 0 {
+		base += "\n\n" + formatBlocked(plan.Blocked)
+	}
+
+	if len(plan.NodeUpdate) == 0 {
+		return base + "\n\nNo changes in the instances"
+	}
+
+	rejected := map[string]struct{}{}
+	for _, id := range plan.Rejected {
+		rejected[id] = struct{}{}
+	}
+
+	rows = make([]string, len(plan.NodeUpdate)+1)
+	rows[0] = "Action|ID|Name|Group|Applied"
+	for i, n := range plan.NodeUpdate {
+		_, ok := rejected[n.ID]
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%v",
+			planAction(n),
+			n.ID,
+			n.Name,
+			n.Group.GetType(),
+			!plan.Stale && !ok,
+		)
+	}
+	return base + "\n\n" + formatList(rows)
+}
+
+// planAction returns the action of the scheduler for an instance in a plan
+func planAction(n *proto.Instance) string {
+	switch {
+	case n.ModifyIndex == 0:
+		// the instance is not in the state yet
+		return "place"
+	case n.Status == proto.Instance_OUT:
+		return "out"
+	case n.DesiredStatus == proto.Instance_STOP:
+		return "stop"
+	default:
+		return "promote"
+	}
+}
+
+// formatPlanSummary returns the number of instances of each action in the plan
+func formatPlanSummary(plan *proto.Plan) string {
+	if plan == nil || len(plan.NodeUpdate) == 0 {
+		return ""
+	}
+	count := map[string]int{}
+	for _, n := range plan.NodeUpdate {
+		count[planAction(n)]++
+	}
+	res := []string{}
+	for _, action := range []string{"place", "stop", "promote", "out"} {
+		if num, ok := count[action]; ok {
+			res = append(res, fmt.Sprintf("%s %d", action, num))
+		}
+	}
+	return strings.Join(res, ", ")
+}
+
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ptypes.TimestampString(ts)
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Flagset struct {
@@ -93,3 +94,18 @@ func (f *Flagset) IntFlag(i *IntFlag) {
 	})
 	f.set.IntVar(i.Value, i.Name, i.Default, i.Usage)
 }
+
+type DurationFlag struct {
+	Name    string
+	Usage   string
+	Default time.Duration
+	Value   *time.Duration
+}
+
+func (f *Flagset) DurationFlag(d *DurationFlag) {
+	f.addFlag(&FlagVar{
+		Name:  d.Name,
+		Usage: d.Usage,
+	})
+	f.set.DurationVar(d.Value, d.Name, d.Default, d.Usage)
+}
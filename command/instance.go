@@ -0,0 +1,30 @@
+package command
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+type InstanceCommand struct {
+	UI cli.Ui
+}
+
+// Help implements the cli.Command interface
+func (c *InstanceCommand) Help() string {
+	return `Usage: ensemble instance <subcommand>
+
+  This command groups actions to interact with instances.
+
+  Replace an instance:
+
+    $ ensemble instance replace <instance_id>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *InstanceCommand) Synopsis() string {
+	return "Interact with instances"
+}
+
+// Run implements the cli.Command interface
+func (c *InstanceCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
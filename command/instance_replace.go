@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type InstanceReplaceCommand struct {
+	Meta
+
+	wipe bool
+}
+
+// Help implements the cli.Command interface
+func (c *InstanceReplaceCommand) Help() string {
+	return `Usage: ensemble instance replace [options] <id>
+
+  Replace an instance with a new one with the same name.
+
+  Replace the instance and keep its volumes:
+
+    $ ensemble instance replace <instance_id>
+
+  Replace the instance with empty volumes, the volumes of the instance are deleted:
+
+    $ ensemble instance replace -wipe <instance_id>
+
+` + c.Flags().Help()
+}
+
+func (c *InstanceReplaceCommand) Flags() *flagset.Flagset {
+	f := c.NewFlagSet("instance replace")
+
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:  "wipe",
+		Value: &c.wipe,
+		Usage: "Do not reuse the volumes of the instance and delete them",
+	})
+
+	return f
+}
+
+// Synopsis implements the cli.Command interface
+func (c *InstanceReplaceCommand) Synopsis() string {
+	return "Replace an instance"
+}
+
+// Run implements the cli.Command interface
+func (c *InstanceReplaceCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <id> expected")
+		return 1
+	}
+	instanceID := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	instance, err := clt.ReplaceInstance(context.Background(), &proto.ReplaceInstanceReq{Id: instanceID, Wipe: c.wipe})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Instance %s (%s) marked for replacement", instance.ID, instance.Name))
+	return 0
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+type OperatorCommand struct {
+	UI cli.Ui
+}
+
+// Help implements the cli.Command interface
+func (c *OperatorCommand) Help() string {
+	return `Usage: ensemble operator <subcommand>
+
+  This command groups actions to manage the Ensemble operator.
+
+  Save a snapshot of the state of the operator:
+
+    $ ensemble operator snapshot save <file>
+
+  Restore a snapshot in a new operator:
+
+    $ ensemble operator snapshot restore <file>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *OperatorCommand) Synopsis() string {
+	return "Manage the Ensemble operator"
+}
+
+// Run implements the cli.Command interface
+func (c *OperatorCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
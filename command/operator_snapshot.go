@@ -0,0 +1,36 @@
+package command
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+type OperatorSnapshotCommand struct {
+	UI cli.Ui
+}
+
+// Help implements the cli.Command interface
+func (c *OperatorSnapshotCommand) Help() string {
+	return `Usage: ensemble operator snapshot <subcommand>
+
+  This command groups actions to save and restore snapshots of the state
+  of the operator. A snapshot includes the deployments, the history of
+  the components and the instances.
+
+  Save a snapshot:
+
+    $ ensemble operator snapshot save <file>
+
+  Restore a snapshot:
+
+    $ ensemble operator snapshot restore <file>`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *OperatorSnapshotCommand) Synopsis() string {
+	return "Save and restore snapshots of the operator state"
+}
+
+// Run implements the cli.Command interface
+func (c *OperatorSnapshotCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/teseraio/ensemble/command/flagset"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+type OperatorSnapshotRestoreCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *OperatorSnapshotRestoreCommand) Help() string {
+	return `Usage: ensemble operator snapshot restore <file>
+
+  Restore a snapshot of the state. The operator must not have any
+  deployment.
+
+` + c.Flags().Help()
+}
+
+func (c *OperatorSnapshotRestoreCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("operator snapshot restore")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *OperatorSnapshotRestoreCommand) Synopsis() string {
+	return "Restore a snapshot of the operator state"
+}
+
+// Run implements the cli.Command interface
+func (c *OperatorSnapshotRestoreCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <file> expected")
+		return 1
+	}
+	path := args[0]
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("failed to read the snapshot: %v", err))
+		return 1
+	}
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	stream, err := clt.SnapshotRestore(context.Background())
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	for len(data) != 0 {
+		size := snapshotChunkSize
+		if len(data) < size {
+			size = len(data)
+		}
+		if err := stream.Send(&proto.SnapshotChunk{Data: data[:size]}); err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		data = data[size:]
+	}
+	if _, err := stream.CloseAndRecv(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output("Snapshot restored")
+	return 0
+}
+
+// snapshotChunkSize is the size of the chunks used to stream the snapshot
+const snapshotChunkSize = 512 * 1024
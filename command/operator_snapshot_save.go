@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/teseraio/ensemble/command/flagset"
+)
+
+type OperatorSnapshotSaveCommand struct {
+	Meta
+}
+
+// Help implements the cli.Command interface
+func (c *OperatorSnapshotSaveCommand) Help() string {
+	return `Usage: ensemble operator snapshot save <file>
+
+  Save a snapshot of the state of the operator in a file.
+
+` + c.Flags().Help()
+}
+
+func (c *OperatorSnapshotSaveCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("operator snapshot save")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *OperatorSnapshotSaveCommand) Synopsis() string {
+	return "Save a snapshot of the operator state"
+}
+
+// Run implements the cli.Command interface
+func (c *OperatorSnapshotSaveCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("argument <file> expected")
+		return 1
+	}
+	path := args[0]
+
+	clt, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	stream, err := clt.SnapshotSave(context.Background(), &empty.Empty{})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	data := []byte{}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		data = append(data, chunk.Data...)
+	}
+
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		c.UI.Error(fmt.Sprintf("failed to write the snapshot: %v", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Snapshot saved in %s", path))
+	return 0
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/teseraio/ensemble/command/flagset"
 	"github.com/teseraio/ensemble/k8s"
 	"github.com/teseraio/ensemble/operator"
+	"github.com/teseraio/ensemble/operator/state"
 	"github.com/teseraio/ensemble/operator/state/boltdb"
 
 	"github.com/google/gops/agent"
@@ -26,6 +27,12 @@ type Command struct {
 	logLevel   string
 	boltdbPath string
 	bind       string
+
+	retainVersions  int
+	instanceTTL     time.Duration
+	deleteTTL       time.Duration
+	evalTTL         time.Duration
+	compactInterval time.Duration
 }
 
 // Help implements the cli.Command interface
@@ -66,6 +73,38 @@ func (c *Command) Flags() *flagset.Flagset {
 		Default: "127.0.0.1",
 	})
 
+	f.IntFlag(&flagset.IntFlag{
+		Name:  "retain-versions",
+		Value: &c.retainVersions,
+		Usage: "Number of versions to keep for each component (0 keeps all)",
+	})
+
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:  "instance-ttl",
+		Value: &c.instanceTTL,
+		Usage: "Time to keep the instances that are out of the cluster (0 keeps them)",
+	})
+
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:  "delete-ttl",
+		Value: &c.deleteTTL,
+		Usage: "Time to keep the deleted clusters and resources (0 keeps them)",
+	})
+
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "eval-ttl",
+		Value:   &c.evalTTL,
+		Usage:   "Time to keep the evaluations once they finish (0 keeps them)",
+		Default: 24 * time.Hour,
+	})
+
+	f.DurationFlag(&flagset.DurationFlag{
+		Name:    "compact-interval",
+		Value:   &c.compactInterval,
+		Usage:   "Interval between compactions of the state",
+		Default: time.Hour,
+	})
+
 	return f
 }
 
@@ -102,7 +141,7 @@ func (c *Command) Run(args []string) int {
 	}
 
 	// setup state
-	state, err := boltdb.Factory(map[string]interface{}{
+	db, err := boltdb.Factory(map[string]interface{}{
 		"path": c.boltdbPath,
 	})
 	if err != nil {
@@ -112,9 +151,18 @@ func (c *Command) Run(args []string) int {
 
 	config := &operator.Config{
 		Provider:         k8sProvider,
-		State:            state,
+		State:            db,
 		HandlerFactories: BuiltinBackends,
 		GRPCAddr:         &net.TCPAddr{IP: net.ParseIP(c.bind), Port: 6001},
+		CompactInterval:  c.compactInterval,
+	}
+	if c.retainVersions != 0 || c.instanceTTL != 0 || c.deleteTTL != 0 || c.evalTTL != 0 {
+		config.Retention = &state.RetentionPolicy{
+			ComponentVersions: int64(c.retainVersions),
+			InstanceTTL:       c.instanceTTL,
+			DeleteTTL:         c.deleteTTL,
+			EvaluationTTL:     c.evalTTL,
+		}
 	}
 	srv, err := operator.NewServer(logger, config)
 	if err != nil {
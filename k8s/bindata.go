@@ -113,7 +113,7 @@ func resourcesConfigMapTemplate() (*asset, error) {
 	return a, nil
 }
 
-var _resourcesCrdClusterJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x57\x4f\x8b\xdb\x3e\x10\x3d\xcb\x9f\x42\xe8\x1c\xf2\xe3\x47\x2f\x25\xb7\xd2\x42\xe9\xa5\x2c\x2d\xec\x65\xd9\xc3\xc4\x9e\xa6\x6a\x6c\x49\x1d\x49\xa1\xcb\xe2\xef\x5e\xe4\x3f\x5a\xe7\xef\x4a\x36\x14\xd6\xa7\x58\x33\x6f\xf4\xf4\xf4\x32\xb2\x9e\x0b\x26\xc0\xc8\x7b\x24\x2b\xb5\x12\x1b\x1e\xde\xf0\x8f\x43\x15\xde\xed\x7a\xff\xde\xae\xa5\xfe\xef\xf0\xbf\x58\x15\x4c\xec\xa5\xaa\x42\xce\x47\x6f\x9d\x6e\xbe\xa1\xd5\x9e\x4a\xfc\x84\x3f\xa4\x92\x2e\xe0\x43\x52\x83\x0e\x2a\x70\x20\x36\xfc\xb9\x60\x4c\x28\x68\x30\x80\xca\xda\x5b\x87\x64\xd7\xa8\x2c\x36\xdb\x1a\xb5\x0d\xb5\x45\xc1\x39\xe7\x6d\x40\x5a\x83\xe5\x88\xda\x91\xf6\x26\xc0\x4e\xb2\x57\x21\x78\xe8\xe9\x5a\xb1\xe1\x0f\x05\x63\x2c\x20\x26\x33\xf5\x6c\xc3\x88\x45\x3a\x60\xa0\xec\xc8\xe3\x38\xe6\x34\xc1\x0e\x4f\x06\xcb\x9f\xd8\x8c\x94\xc3\x80\x36\xa8\x3e\xdc\x7d\xb9\x7f\xf7\xfd\x25\xc2\xaf\x3c\xc2\x3d\x99\x6e\x62\xbd\xfd\x85\xa5\x13\xab\xeb\x99\x86\xb4\x41\x72\x12\xed\xcd\x8a\x5d\x6e\x94\xe3\x56\x56\xde\xfc\x73\x78\x44\xcc\x16\xca\x3d\x76\x06\x48\x03\xcc\xa3\xb6\x84\x62\xc4\x0e\x4e\xc8\x43\x1d\xf1\xb5\x8e\xa4\xda\x89\xac\x02\x6d\x72\x76\x9b\xa1\x03\xe1\x6f\x2f\xa9\x73\xf1\x43\xbf\xb0\xc7\x24\x70\xe2\x1c\xfd\x3f\x2d\x4f\xe3\xa8\x12\x10\xc1\x53\xce\xa6\x4a\x87\xcd\x8c\xfd\x9c\xed\x22\xbe\xd0\x49\x7c\x91\x9b\xf8\x52\x47\xf1\x3c\xaf\x9c\xce\xf9\x86\x18\x13\x9a\x5a\x96\x30\x6f\x87\xf8\x94\xb5\x54\x0e\x77\x48\xff\x86\xb6\x01\x82\x19\x86\x8e\xf8\x45\xc6\x8e\x55\xa0\xaa\xba\xf3\x17\xea\xbb\x65\x56\x3f\xe3\x35\xd7\x02\x3c\xab\x1d\xce\x43\x64\xee\xd7\x51\x23\x5d\x60\xd0\x2c\x68\x5a\xab\xe6\xc9\x6b\x4f\x6d\xea\x15\x1a\x54\xd5\x1b\xe9\xea\xb9\x26\x4b\x94\xea\xd5\xac\x04\x31\x8f\x4f\xdf\xf1\x0b\xe8\xf6\xae\xbe\x52\x56\x58\x07\xce\xa7\xc9\x75\xde\x1f\xd8\xf0\x9c\x1e\x6a\x2c\x06\xf4\xb6\xff\xea\xfd\x8c\x0a\x09\x5c\xff\x59\x3f\x49\x60\xe7\x9d\x32\x86\xda\xe2\xe5\xd7\xcd\x25\x5e\x8d\x5e\x8e\x9c\x8f\x5e\x10\x49\x58\xbf\xa5\xe1\x4e\x71\x5d\x9e\x89\x7c\x17\xaa\x16\x97\xdf\x1e\xbb\x6b\x83\x2d\x75\xbf\xf0\xaf\xd0\xa0\x35\x50\x62\xd5\xdf\x27\xc2\x01\x1f\x65\x14\xa6\xf6\x04\xf5\xf4\xca\xd2\xeb\x2e\xac\x54\x3b\x5f\x03\x4d\x42\x43\x24\x5e\x8c\x86\xd1\x62\x14\xb0\x2d\xda\xbf\x01\x00\x00\xff\xff\x9a\x82\x55\x92\x63\x0d\x00\x00")
+var _resourcesCrdClusterJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x59\xcd\x6e\xdb\x30\x0c\x3e\x4b\x4f\x61\xe8\x1c\x74\x18\x76\x19\x7a\x2b\x5a\x60\xd8\x65\x2b\x56\xb4\x97\x22\x07\xda\xe6\x1c\xad\xfa\xf1\x44\x39\x6d\x50\xf8\xdd\x07\xc5\x71\x9a\xa4\xe9\x60\x4b\x6e\x87\x4e\xbe\xd8\x92\x28\x7d\x24\x3f\x52\xb2\xf4\xc8\x99\x80\x5a\xde\xa0\x23\x69\x8d\x38\xcd\xc2\x17\x3e\x78\x34\xe1\x9b\x4e\xee\x3e\xd3\x89\xb4\x1f\x96\x1f\xc5\x8c\x33\x71\x27\x4d\x19\xfa\x9c\x37\xe4\xad\xfe\x81\x64\x1b\x57\xe0\x05\xfe\x94\x46\xfa\x20\x1f\x3a\x69\xf4\x50\x82\x07\x71\x9a\x3d\x72\xc6\x84\x01\x8d\x41\xa8\x50\x0d\x79\x74\x74\x82\x86\x50\xe7\x0a\x2d\x85\xb1\x05\xcf\xb2\x2c\x6b\x83\x24\xd5\x58\xf4\x52\x95\xb3\x4d\x1d\xc4\x0e\x7a\xcf\x42\xe3\xb2\x83\x4b\xe2\x34\xbb\xe5\x8c\xb1\x30\xcf\xce\x4c\x1d\x5a\xc6\x98\x20\x74\x4b\x0c\x90\xbd\x6b\xb0\xaf\xf3\xd6\x41\x85\x07\x95\xc5\x02\x75\x0f\x39\x48\xda\x1a\xcd\xd9\xe5\xd7\x9b\x4f\x57\x4f\x2d\xd9\x0b\x45\xf8\x55\x1d\xc6\x13\x36\xff\x85\x85\x17\xb3\x97\x7b\xd6\xce\xd6\xe8\xbc\x44\xfa\xeb\x88\xe1\x79\x32\x47\x5f\x93\x3e\x7f\x0c\x8e\xbe\x88\x1c\x8a\x3b\x34\xe5\x60\x81\x38\x68\x29\x10\xfb\xd2\x33\x61\x9c\xd4\x1e\x5e\xf2\x4e\x9a\x4a\x8c\x1a\xa0\x1d\xdc\xbb\x1d\x61\x07\x87\xbf\x1b\xe9\xd6\x2c\xbe\xed\x14\x9b\xf3\x09\xe7\xe8\x22\x8d\xe2\xbc\x0a\xce\xc1\x6a\x8c\x53\xa5\x47\x1d\xe1\xcf\x68\x16\xa5\x32\x29\x8d\x4d\xc9\x8c\x1a\xc9\x95\xc3\x39\xdf\x11\x62\x87\xb5\x92\x05\x50\x3a\x6a\x69\x3c\x56\xe8\xde\x06\xb6\x96\xe6\x6c\x09\x52\x41\xae\xf0\xbd\x41\x87\x87\x6b\x03\xef\x14\x7c\x89\x35\x9a\x92\xbe\x9b\x74\xdc\x63\x73\xd8\x04\xf9\xec\x28\x8e\xd8\x68\x1b\xb7\xf2\xa4\x18\x1d\x1a\x6f\xa9\x80\x29\xc8\x12\x95\xc8\xa7\x4a\xe8\x7d\x11\x5a\xc6\xf3\xe7\x99\x4a\xd1\xfc\x4f\x70\x49\xff\x08\x0d\x0f\xff\x8b\x26\xe8\x9d\x1c\xb6\xef\x1d\xa4\x4c\x4a\x58\xa5\xea\xe2\xc1\x55\xe8\xa7\xd3\xc5\x34\x3a\xff\x57\x7e\x29\xac\x55\xa5\xbd\x37\x57\x58\x58\x53\xd2\x74\x4a\xa5\xb3\x8d\xbf\xa1\x2d\xf6\xb6\xe3\x69\x99\x27\xc1\x19\x21\xdc\x53\xc4\xbb\x18\x4b\x67\x76\xd4\x00\x73\x3e\x56\x24\xc2\x57\xeb\x0d\x25\x14\x78\x6d\x16\x08\xca\x2f\x56\xa9\xbc\x4d\xe7\x6b\x8c\x16\x35\x38\xd0\x13\x80\x4e\x5b\x6f\xa1\x2c\xd7\xe7\x3b\xa0\x2e\x27\x5a\x79\xa7\xc8\xce\x2d\x7f\x5d\x89\x91\xfe\x4a\xcb\x0c\x4f\x3f\x40\xfc\x75\x62\xa9\xe5\x13\xea\xdc\xef\xbf\x47\x91\x20\x7a\xc7\x9d\x7a\x6a\x30\x96\x64\xd3\x9a\xaa\x76\xb6\x72\x48\x74\x81\x50\x2a\x69\x30\x26\x11\xc5\x25\x9f\xe1\x08\xa5\x75\xd2\xaf\xce\x15\x50\x24\xae\x8d\x89\x87\x4d\x18\x1e\x81\xa6\xd1\x61\x0d\x15\xca\xde\x8b\x59\x26\x8c\x75\x1a\x54\x78\x5b\xc8\x6a\x31\xf4\x8c\x8b\x4f\x60\x83\xbd\xc0\xdd\x9e\x72\xce\x79\xc2\xb0\x82\x3c\xf8\x66\x98\x2d\x9f\xe7\x68\xb6\x29\x87\xff\x39\x7d\x3d\x13\x36\xef\x4e\xb6\xbf\xa0\x41\x07\x21\x35\xef\x77\x60\xcf\x09\xd3\xb7\xb0\x76\xfb\xda\xf2\x38\xdb\xb6\x7c\x58\xed\x11\x23\x09\x6a\x72\xb7\xb9\x37\x78\xd9\x3c\x3b\xe6\x3b\x32\x2a\x3f\x3e\xeb\x3c\x18\x4e\x50\x61\xbb\xa0\xff\x06\x1a\xa9\x86\x02\xcb\xf5\x65\xc0\xfa\x10\x6f\x6b\x46\x51\xab\xc6\x81\xda\xbd\x96\x58\xf7\x62\x82\xa4\xa9\x1a\x05\x6e\xa7\x69\xd3\xb2\xbd\xfc\xd8\xd4\x72\xc6\x5a\x9e\x65\x59\xd6\xf2\xf6\xcf\x00\x47\x1d\x1c\x8f\x47\x19\x00\x00")
 
 func resourcesCrdClusterJsonBytes() ([]byte, error) {
 	return bindataRead(
@@ -128,7 +128,7 @@ func resourcesCrdClusterJson() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "resources/crd-cluster.json", size: 3427, mode: os.FileMode(436), modTime: time.Unix(1625668177, 0)}
+	info := bindataFileInfo{name: "resources/crd-cluster.json", size: 6471, mode: os.FileMode(436), modTime: time.Unix(1792429202, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -71,7 +71,7 @@ func MarshalPod(i *proto.Instance) ([]byte, error) {
 		volumes = append(volumes, map[string]interface{}{
 			"name": m.Name,
 			"persistentVolumeClaim": map[string]interface{}{
-				"claimName": i.Name + "-" + m.Name,
+				"claimName": i.VolumeName(m),
 			},
 		})
 	}
@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -114,11 +115,13 @@ func (p *Provider) Setup(cplane operator.ControlPlane) error {
 		}
 	})
 
-	ch := cplane.SubscribeInstanceUpdates()
+	sub := cplane.SubscribeInstanceUpdates()
 	go func() {
+		defer sub.Close()
+
 		for {
-			msg := <-ch
-			if msg == nil {
+			msg, err := sub.Next(context.Background())
+			if err != nil {
 				return
 			}
 			instance, err := cplane.GetInstance(msg.InstanceID)
@@ -296,7 +299,7 @@ func (p *Provider) createHeadlessService(cluster string) error {
 }
 
 func (p *Provider) createVolume(instance *proto.Instance, m *proto.Instance_Mount) error {
-	claimName := instance.Name + "-" + m.Name
+	claimName := instance.VolumeName(m)
 
 	res, err := RunTmpl2("volume-claim", map[string]interface{}{
 		"Name":        claimName,
@@ -314,6 +317,24 @@ func (p *Provider) createVolume(instance *proto.Instance, m *proto.Instance_Moun
 	return nil
 }
 
+// deleteWipedVolumes deletes the volume claims of the previous instance that
+// are not used anymore since the volumes were wiped on the replace
+func (p *Provider) deleteWipedVolumes(instance *proto.Instance) error {
+	prev, err := p.cplane.GetInstance(instance.Prev)
+	if err != nil {
+		return err
+	}
+	for _, m := range instance.WipedMounts(prev) {
+		claimName := prev.VolumeName(m)
+		if err := p.delete("/api/v1/namespaces/{namespace}/persistentvolumeclaims/"+claimName, emptyDel); err != nil {
+			if err != errNotFound {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (p *Provider) Name() string {
 	return "Kubernetes"
 }
@@ -369,6 +390,11 @@ func (p *Provider) createPod(node *proto.Instance) error {
 			}
 		}
 	}
+	if node.Prev != "" {
+		if err := p.deleteWipedVolumes(node); err != nil {
+			p.logger.Error("failed to delete the wiped volumes", "id", node.Prev, "err", err)
+		}
+	}
 
 	data, err := MarshalPod(node)
 	if err != nil {
@@ -82,6 +82,7 @@ func (p *Provider) trackCRDs(clt proto.EnsembleServiceClient) error {
 			Spec:     spec,
 			Metadata: item.Metadata.Labels,
 			Action:   action,
+			Cascade:  item.Metadata.Labels[cascadeDeleteLabel] == "true",
 		}
 
 		p.logger.Debug("apply component", "name", item.Metadata.Name, "kind", item.Kind, "action", task.typ)
@@ -109,6 +110,10 @@ func (p *Provider) trackCRDs(clt proto.EnsembleServiceClient) error {
 	return nil
 }
 
+// cascadeDeleteLabel is the label of a cluster to delete the clusters
+// that depend on it too
+const cascadeDeleteLabel = "ensembleoss.io/cascade-delete"
+
 func DecodeItem(item *Item) (*any.Any, error) {
 	if item.Kind == "Cluster" {
 		return DecodeClusterSpec(item)
@@ -126,12 +131,25 @@ func DecodeClusterSpec(item *Item) (*any.Any, error) {
 			Name string
 		}
 		Groups []struct {
-			Type     string
-			Name     string
-			Replicas uint64
-			Params   map[string]interface{}
+			Type           string
+			Name           string
+			Replicas       uint64
+			MinAvailable   uint64
+			MaxUnavailable uint64
+			DependsOn      []string
+			Autoscale      *struct {
+				Min             uint64
+				Max             uint64
+				Metric          string
+				Target          float64
+				CooldownSeconds uint64
+			}
+			ReplaceUnhealthySeconds uint64
+			Params                  map[string]interface{}
 		}
-		Depends []string
+		Depends                 []string
+		ProgressDeadlineSeconds uint64
+		PriorityClass           string
 	}
 	if err := mapstructure.Decode(item.Spec, &spec); err != nil {
 		return nil, err
@@ -140,8 +158,21 @@ func DecodeClusterSpec(item *Item) (*any.Any, error) {
 	var groups []*proto.ClusterSpec_Group
 	for _, s := range spec.Groups {
 		grp := &proto.ClusterSpec_Group{
-			Count: int64(s.Replicas),
-			Type:  s.Type,
+			Count:                   int64(s.Replicas),
+			Type:                    s.Type,
+			MinAvailable:            int64(s.MinAvailable),
+			MaxUnavailable:          int64(s.MaxUnavailable),
+			DependsOn:               s.DependsOn,
+			ReplaceUnhealthySeconds: int64(s.ReplaceUnhealthySeconds),
+		}
+		if s.Autoscale != nil {
+			grp.Autoscale = &proto.ClusterSpec_Autoscale{
+				Min:             int64(s.Autoscale.Min),
+				Max:             int64(s.Autoscale.Max),
+				Metric:          s.Autoscale.Metric,
+				Target:          s.Autoscale.Target,
+				CooldownSeconds: int64(s.Autoscale.CooldownSeconds),
+			}
 		}
 		if len(s.Params) != 0 {
 			grp.Params = schema.MapToSpec(s.Params)
@@ -149,9 +180,11 @@ func DecodeClusterSpec(item *Item) (*any.Any, error) {
 		groups = append(groups, grp)
 	}
 	res := proto.MustMarshalAny(&proto.ClusterSpec{
-		Backend:   spec.Backend.Name,
-		Groups:    groups,
-		DependsOn: spec.Depends,
+		Backend:                 spec.Backend.Name,
+		Groups:                  groups,
+		DependsOn:               spec.Depends,
+		ProgressDeadlineSeconds: int64(spec.ProgressDeadlineSeconds),
+		PriorityClass:           spec.PriorityClass,
 	})
 	return res, nil
 }
@@ -0,0 +1,182 @@
+package operator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// autoscaleInterval is the interval between evaluations of the autoscale policies
+var autoscaleInterval = 30 * time.Second
+
+func (s *Server) autoscaler() {
+	ticker := time.NewTicker(autoscaleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.autoscale(time.Now())
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// autoscale evaluates the autoscale policies of all the deployments that
+// are not being reconciled at the moment
+func (s *Server) autoscale(now time.Time) {
+	deps, err := s.State.ListDeployments()
+	if err != nil {
+		s.logger.Error("failed to list deployments", "err", err)
+		return
+	}
+	for _, dep := range deps {
+		if dep == nil || dep.Status != proto.DeploymentDone || dep.Paused {
+			continue
+		}
+		if err := s.autoscaleDeployment(dep.Id, now); err != nil {
+			s.logger.Error("failed to autoscale deployment", "id", dep.Id, "err", err)
+		}
+	}
+}
+
+func (s *Server) autoscaleDeployment(deploymentID string, now time.Time) error {
+	dep, err := s.LoadDeployment(deploymentID)
+	if err != nil {
+		return err
+	}
+	_, spec, err := s.latestClusterSpec(deploymentID)
+	if err != nil {
+		return err
+	}
+	handler, err := s.GetHandler(spec.Backend)
+	if err != nil {
+		return err
+	}
+	metrics, ok := handler.(MetricsHandler)
+	if !ok {
+		return nil
+	}
+
+	var lastScale map[string]time.Time
+	for _, grp := range spec.Groups {
+		policy := grp.Autoscale
+		if policy == nil {
+			continue
+		}
+		if lastScale == nil {
+			// the cooldown is computed from the versions of the spec
+			// so that it is kept if the operator restarts
+			if lastScale, err = s.lastScaleTimes(deploymentID); err != nil {
+				return err
+			}
+		}
+		if last, ok := lastScale[grp.Type]; ok {
+			if now.Sub(last) < time.Duration(policy.CooldownSeconds)*time.Second {
+				continue
+			}
+		}
+
+		value, err := metrics.Metric(dep, grp, policy.Metric)
+		if err != nil {
+			s.logger.Error("failed to read the autoscale metric", "id", deploymentID, "group", grp.Type, "metric", policy.Metric, "err", err)
+			continue
+		}
+		count := autoscaleCount(policy, value)
+		if count == grp.Count {
+			continue
+		}
+
+		s.logger.Info("autoscale group", "id", deploymentID, "group", grp.Type, "metric", policy.Metric, "value", value, "from", grp.Count, "to", count)
+		if _, err := s.Scale(deploymentID, grp.Type, count); err != nil {
+			s.logger.Error("failed to autoscale group", "id", deploymentID, "group", grp.Type, "err", err)
+			continue
+		}
+
+		// each scale applies a new version of the spec, the other groups
+		// are evaluated once this one is deployed
+		return nil
+	}
+	return nil
+}
+
+// lastScaleTimes returns for each group the time of the last version of
+// the deployment that changed its number of instances
+func (s *Server) lastScaleTimes(deploymentID string) (map[string]time.Time, error) {
+	comp, err := s.State.ReadDeployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if comp == nil {
+		return nil, fmt.Errorf("deployment does not exists '%s'", deploymentID)
+	}
+	versions, err := s.State.GetComponentVersions(deploymentID, comp.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]time.Time{}
+	var prev map[string]int64
+	for _, version := range versions {
+		if version.Action == proto.Component_DELETE {
+			continue
+		}
+		var spec proto.ClusterSpec
+		if err := gproto.Unmarshal(version.Spec.Value, &spec); err != nil {
+			return nil, err
+		}
+		counts := map[string]int64{}
+		for _, grp := range spec.Groups {
+			counts[grp.Type] = grp.Count
+			if prevCount, ok := prev[grp.Type]; ok && prevCount != grp.Count {
+				timestamp, err := ptypes.Timestamp(version.Timestamp)
+				if err != nil {
+					return nil, err
+				}
+				res[grp.Type] = timestamp
+			}
+		}
+		prev = counts
+	}
+	return res, nil
+}
+
+// autoscaleCount returns the number of instances required to keep the
+// value of the metric per instance close to the target
+func autoscaleCount(policy *proto.ClusterSpec_Autoscale, value float64) int64 {
+	count := int64(math.Ceil(value / policy.Target))
+	if count < policy.Min {
+		count = policy.Min
+	}
+	if count > policy.Max {
+		count = policy.Max
+	}
+	return count
+}
+
+func validateAutoscale(policy *proto.ClusterSpec_Autoscale) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Min < 1 {
+		return fmt.Errorf("min must be at least 1")
+	}
+	if policy.Max < policy.Min {
+		return fmt.Errorf("max cannot be lower than min")
+	}
+	if policy.Metric == "" {
+		return fmt.Errorf("metric not set")
+	}
+	if policy.Target <= 0 {
+		return fmt.Errorf("target must be positive")
+	}
+	if policy.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldownSeconds cannot be negative")
+	}
+	return nil
+}
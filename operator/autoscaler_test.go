@@ -0,0 +1,134 @@
+package operator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestAutoscaleCount(t *testing.T) {
+	policy := &proto.ClusterSpec_Autoscale{
+		Min:    2,
+		Max:    5,
+		Target: 10,
+	}
+
+	cases := []struct {
+		value float64
+		count int64
+	}{
+		{0, 2},
+		{25, 3},
+		{30, 3},
+		{31, 4},
+		{100, 5},
+	}
+	for _, c := range cases {
+		assert.Equal(t, autoscaleCount(policy, c.value), c.count)
+	}
+}
+
+// metricsHandler returns a fixed value for any metric
+// unless the group is set as failed
+type metricsHandler struct {
+	nullHandler
+	value  float64
+	failed string
+}
+
+func (m *metricsHandler) Metric(dep *proto.Deployment, grp *proto.ClusterSpec_Group, name string) (float64, error) {
+	if grp.Type == m.failed {
+		return 0, fmt.Errorf("metric not available")
+	}
+	return m.value, nil
+}
+
+func TestServer_Autoscale(t *testing.T) {
+	h := &metricsHandler{value: 25, failed: "x"}
+	s := testServer(t, h)
+
+	_, err := s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1, Autoscale: &proto.ClusterSpec_Autoscale{
+					Min:    1,
+					Max:    5,
+					Metric: "m",
+					Target: 10,
+				}},
+				{Type: "y", Count: 1, Autoscale: &proto.ClusterSpec_Autoscale{
+					Min:             1,
+					Max:             5,
+					Metric:          "m",
+					Target:          10,
+					CooldownSeconds: 60,
+				}},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := s.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	groupCount := func() int64 {
+		_, spec, err := s.latestClusterSpec(depID)
+		assert.NoError(t, err)
+		return spec.Groups[1].Count
+	}
+
+	now := time.Now()
+
+	// the deployment is not done yet
+	s.autoscale(now)
+	assert.Equal(t, groupCount(), int64(1))
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:     depID,
+		Name:   "a",
+		Status: proto.DeploymentDone,
+	}))
+
+	// the metric of the first group fails, the second one is scaled
+	s.autoscale(now)
+	assert.Equal(t, groupCount(), int64(3))
+
+	// the cooldown period has not expired yet
+	h.value = 50
+	s.autoscale(now.Add(30 * time.Second))
+	assert.Equal(t, groupCount(), int64(3))
+
+	// the cooldown is kept if the operator restarts
+	s2 := testServer(t, h)
+	s2.State = s.State
+	s2.autoscale(now.Add(30 * time.Second))
+	assert.Equal(t, groupCount(), int64(3))
+
+	s.autoscale(now.Add(61 * time.Second))
+	assert.Equal(t, groupCount(), int64(5))
+
+	// the group whose metric fails is not modified
+	_, spec, err := s.latestClusterSpec(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, spec.Groups[0].Count, int64(1))
+
+	// the policy is validated
+	_, err = s.validateComponent(&proto.Component{
+		Name: "b",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1, Autoscale: &proto.ClusterSpec_Autoscale{
+					Min:    3,
+					Max:    2,
+					Metric: "m",
+					Target: 10,
+				}},
+			},
+		}),
+	})
+	assert.Error(t, err)
+}
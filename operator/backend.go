@@ -1,8 +1,11 @@
 package operator
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/teseraio/ensemble/operator/proto"
 	"github.com/teseraio/ensemble/schema"
 )
@@ -90,7 +93,32 @@ type Spec struct {
 	Resources []*Resource2
 	Validate  func(comp *proto.Component) (*proto.Component, error)
 	Handlers  map[string]func(spec *proto.NodeSpec, grp *proto.ClusterSpec_Group, data *schema.ResourceData)
-	Startup   func(i *proto.Instance) error
+
+	// Startup returns once the instance is ready. It runs in the background for each
+	// instance and it is retried with the StartupProbe settings (DefaultStartupProbe
+	// if not set). If it fails, the instance is marked with the error. The instance
+	// is a copy and any change done to it is discarded.
+	Startup      func(ctx context.Context, i *proto.Instance) error
+	StartupProbe *Probe
+
+	// HealthCheck checks periodically the instances once they are healthy
+	HealthCheck *HealthCheck
+
+	// Status returns the status of the cluster from the point of view of the backend
+	Status func(ctx context.Context, req *StatusRequest) (*proto.BackendStatus, error)
+
+	// Metrics are the metrics of the cluster that can be used to autoscale the groups
+	Metrics map[string]func(req *MetricRequest) (float64, error)
+
+	// Outputs are the values of the deployment that other clusters can reference
+	Outputs map[string]*Output
+}
+
+// MetricsHandler is an optional interface for the handlers that expose metrics
+// to autoscale the groups of a cluster
+type MetricsHandler interface {
+	// Metric returns the current value of a metric for a group in the deployment
+	Metric(dep *proto.Deployment, grp *proto.ClusterSpec_Group, name string) (float64, error)
 }
 
 // Nodetype is a type of node for the Backend
@@ -111,6 +139,11 @@ type Nodetype struct {
 
 	// Ports is a list of ports for this node type
 	Ports []*Port
+
+	// DependsOn is the list of node types that have to be healthy before
+	// the nodes of this type are deployed. It is only used if the group
+	// does not specify its own dependencies.
+	DependsOn []string
 }
 
 // Port is an exposed port for the node
@@ -138,7 +171,12 @@ type Resource interface {
 type BaseOperator struct {
 	handler Handler2
 	cplane  ControlPlane
+	logger  hclog.Logger
 	// ch      chan *proto.InstanceUpdate
+
+	// startup probes and health checks running in the background
+	lock  sync.Mutex
+	tasks map[instanceTask]*runningTask
 }
 
 func (b *BaseOperator) SetHandler(h Handler2) {
@@ -148,40 +186,77 @@ func (b *BaseOperator) SetHandler(h Handler2) {
 func (b *BaseOperator) Setup(cplane ControlPlane) {
 	// b.handler.Setup2()
 	b.cplane = cplane
-	stream := cplane.SubscribeInstanceUpdates()
+	b.logger = hclog.Default()
+	if provider, ok := cplane.(LoggerProvider); ok {
+		b.logger = provider.Logger()
+	}
+	sub := cplane.SubscribeInstanceUpdates()
 
 	go func() {
+		// the running probes are cancelled once the subscription is closed
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		defer sub.Close()
+
+		// the probes of the instances of the backend that exist when the
+		// operator starts did not survive the restart, they are run again
+		ids, err := sub.Instances()
+		if err != nil {
+			b.logger.Error("failed to list the instances", "err", err)
+		}
+		for _, id := range ids {
+			if err := b.handleMsg(ctx, id, true); err != nil {
+				b.logger.Error("failed to handle backend message", "id", id, "err", err)
+			}
+		}
+
 		for {
-			msg := <-stream
-			if err := b.handleMsg(msg); err != nil {
-				fmt.Printf("[ERR]: failed to handle backend message: %s", err.Error())
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				return
+			}
+			if err := b.handleMsg(ctx, msg.InstanceID, false); err != nil {
+				b.logger.Error("failed to handle backend message", "id", msg.InstanceID, "err", err)
 			}
 		}
 	}()
 }
 
-func (b *BaseOperator) handleMsg(msg *InstanceUpdate) error {
-	instance, err := b.cplane.GetInstance(msg.InstanceID)
+// handleMsg starts the probes and the checks of the instance. If restored is set,
+// the instance existed before the operator started and the unhealthy instance
+// is checked again.
+func (b *BaseOperator) handleMsg(ctx context.Context, id string, restored bool) error {
+	instance, err := b.cplane.GetInstance(id)
 	if err != nil {
+		b.cancelTasks(id)
 		return err
 	}
-	if instance.Status == proto.Instance_RUNNING && !instance.Healthy {
-		ii := instance.Copy()
-		if err := b.handler.Spec().Startup(ii); err != nil {
-			// move to a failing state directly, or maybe ist just to reuquee the msg
-			return err
-		}
+	if instance == nil || instance.Status != proto.Instance_RUNNING || instance.DesiredStatus == proto.Instance_STOP {
+		// the instance is not running anymore
+		b.cancelTasks(id)
+		return nil
+	}
 
-		// the new instance has to update
-		if !ii.Healthy {
-			return fmt.Errorf("it should be healtyh after startup time")
-		}
-		b.cplane.UpsertInstance(ii)
+	// the probes and the checks run in the background to not
+	// block the updates of the other instances
+	spec := b.handler.Spec()
+	if !instance.Healthy && spec.Startup != nil && (instance.HealthError == "" || restored) {
+		b.startProbe(ctx, instance)
+	} else if spec.HealthCheck != nil && (instance.Healthy || restored) {
+		// the check keeps running while the instance is unhealthy
+		b.startHealthCheck(ctx, instance, spec.HealthCheck)
 	}
 	return nil
 }
 
 func (b *BaseOperator) Evaluate(comp *proto.Component) (*proto.Component, error) {
+	comp, err := b.setGroupDependencies(comp)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validateMetrics(comp); err != nil {
+		return nil, err
+	}
 	valFunc := b.handler.Spec().Validate
 	if valFunc != nil {
 		return valFunc(comp)
@@ -189,6 +264,118 @@ func (b *BaseOperator) Evaluate(comp *proto.Component) (*proto.Component, error)
 	return comp, nil
 }
 
+// setGroupDependencies sets the default dependencies of the node types
+// to the groups in the cluster that do not have any
+func (b *BaseOperator) setGroupDependencies(comp *proto.Component) (*proto.Component, error) {
+	msg, err := proto.UnmarshalAny(comp.Spec)
+	if err != nil {
+		return nil, err
+	}
+	spec, ok := msg.(*proto.ClusterSpec)
+	if !ok {
+		return comp, nil
+	}
+
+	types := map[string]struct{}{}
+	for _, grp := range spec.Groups {
+		types[grp.Type] = struct{}{}
+	}
+
+	changed := false
+	for _, grp := range spec.Groups {
+		if len(grp.DependsOn) != 0 {
+			continue
+		}
+		nodeType, ok := b.handler.Spec().Nodetypes[grp.Type]
+		if !ok {
+			continue
+		}
+		for _, dep := range nodeType.DependsOn {
+			if _, ok := types[dep]; ok {
+				grp.DependsOn = append(grp.DependsOn, dep)
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return comp, nil
+	}
+
+	comp = comp.Copy()
+	comp.Spec = proto.MustMarshalAny(spec)
+	return comp, nil
+}
+
+// validateMetrics checks that the autoscale policies use metrics
+// defined by the backend
+func (b *BaseOperator) validateMetrics(comp *proto.Component) error {
+	msg, err := proto.UnmarshalAny(comp.Spec)
+	if err != nil {
+		return err
+	}
+	spec, ok := msg.(*proto.ClusterSpec)
+	if !ok {
+		return nil
+	}
+	for _, grp := range spec.Groups {
+		if grp.Autoscale == nil {
+			continue
+		}
+		if _, ok := b.handler.Spec().Metrics[grp.Autoscale.Metric]; !ok {
+			return fmt.Errorf("metric '%s' not found for group '%s'", grp.Autoscale.Metric, grp.Type)
+		}
+	}
+	return nil
+}
+
+// MetricRequest is the request to a metric function of a backend
+type MetricRequest struct {
+	Deployment *proto.Deployment
+
+	// Group is the group being autoscaled
+	Group *proto.ClusterSpec_Group
+
+	b *BaseOperator
+}
+
+// Healthy returns the healthy instances of the deployment. If typ
+// is not empty, only the instances of that group are returned.
+func (r *MetricRequest) Healthy(typ string) []*proto.Instance {
+	res := []*proto.Instance{}
+	for _, i := range r.Deployment.Instances {
+		if i.Status != proto.Instance_RUNNING || !i.Healthy || i.DesiredStatus != proto.Instance_RUN {
+			continue
+		}
+		if typ != "" && i.Group.GetType() != typ {
+			continue
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+// Client returns the client of the backend for any of the healthy instances
+func (r *MetricRequest) Client() (interface{}, error) {
+	healthy := r.Healthy("")
+	if len(healthy) == 0 {
+		return nil, fmt.Errorf("no healthy instances found")
+	}
+	return r.b.handler.Client(healthy[0])
+}
+
+// Metric implements the MetricsHandler interface
+func (b *BaseOperator) Metric(dep *proto.Deployment, grp *proto.ClusterSpec_Group, name string) (float64, error) {
+	metric, ok := b.handler.Spec().Metrics[name]
+	if !ok {
+		return 0, fmt.Errorf("metric '%s' not found", name)
+	}
+	return metric(&MetricRequest{
+		Deployment: dep,
+		Group:      grp,
+		b:          b,
+	})
+}
+
 func (b *BaseOperator) GetSchemas() GetSchemasResponse {
 	resp := GetSchemasResponse{
 		Nodes:     map[string]schema.Schema2{},
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"expvar"
+	"time"
+)
+
+// defaultCompactInterval is the interval between compactions of the state
+var defaultCompactInterval = 1 * time.Hour
+
+// compactMetrics are the metrics of the compaction job
+var compactMetrics = expvar.NewMap("ensemble.compaction")
+
+func (s *Server) compactor() {
+	if s.config.Retention == nil {
+		return
+	}
+
+	interval := s.config.CompactInterval
+	if interval == 0 {
+		interval = defaultCompactInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.compact(time.Now()); err != nil {
+				s.logger.Error("failed to compact the state", "err", err)
+			}
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// compact removes the history of the state that is out of the retention policy
+func (s *Server) compact(now time.Time) error {
+	start := time.Now()
+
+	stats, err := s.State.Compact(s.config.Retention, now)
+	if err != nil {
+		compactMetrics.Add("errors", 1)
+		return err
+	}
+
+	duration := time.Since(start)
+	compactMetrics.Add("runs", 1)
+	compactMetrics.Add("components", int64(stats.Components))
+	compactMetrics.Add("requests", int64(stats.Requests))
+	compactMetrics.Add("instances", int64(stats.Instances))
+	compactMetrics.Add("deployments", int64(stats.Deployments))
+	compactMetrics.Add("evaluations", int64(stats.Evaluations))
+
+	lastDuration := new(expvar.Float)
+	lastDuration.Set(duration.Seconds())
+	compactMetrics.Set("last_duration_seconds", lastDuration)
+
+	if !stats.Empty() {
+		s.logger.Info("State compacted", "components", stats.Components, "requests", stats.Requests, "instances", stats.Instances, "deployments", stats.Deployments, "evaluations", stats.Evaluations, "duration", duration)
+	}
+	return nil
+}
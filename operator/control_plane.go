@@ -1,48 +1,87 @@
 package operator
 
 import (
+	"fmt"
 	"sync"
 
+	"github.com/hashicorp/go-hclog"
 	"github.com/teseraio/ensemble/operator/proto"
 )
 
-type InstanceUpdate struct {
-	InstanceID string
-}
+// ErrInstanceModified is returned when the instance changed
+// after it was read
+var ErrInstanceModified = fmt.Errorf("instance modified")
 
 type ControlPlane interface {
 	UpsertInstance(*proto.Instance) error
+
+	// UpdateInstance writes the instance only if it was not modified after
+	// it was read (same ModifyIndex). It returns ErrInstanceModified otherwise.
+	UpdateInstance(*proto.Instance) error
+
 	GetInstance(instanceID string) (*proto.Instance, error)
-	SubscribeInstanceUpdates() <-chan *InstanceUpdate
+
+	// SubscribeInstanceUpdates returns a subscription to the updates
+	// of the instances. It has to be closed once it is not used.
+	SubscribeInstanceUpdates() *Subscription
+}
+
+// LoggerProvider is an optional interface for the control planes
+// that share their logger with the backends
+type LoggerProvider interface {
+	Logger() hclog.Logger
 }
 
 type InmemControlPlane struct {
 	lock      sync.Mutex
 	instances map[string]*proto.Instance
-	subs      []chan *InstanceUpdate
+	events    *eventBus
 }
 
-func (i *InmemControlPlane) UpsertInstance(ii *proto.Instance) error {
-	i.lock.Lock()
-	defer i.lock.Unlock()
-
+func (i *InmemControlPlane) init() {
 	if i.instances == nil {
 		i.instances = map[string]*proto.Instance{}
 	}
-	i.instances[ii.ID] = ii
-	update := &InstanceUpdate{
-		InstanceID: ii.ID,
+	if i.events == nil {
+		i.events = newEventBus(defaultEventBufferSize, i.instanceIDs)
 	}
-	for _, ch := range i.subs {
-		select {
-		case ch <- update:
-		default:
-		}
+}
+
+func (i *InmemControlPlane) UpsertInstance(ii *proto.Instance) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.init()
+	i.upsertLocked(ii)
+	return nil
+}
+
+func (i *InmemControlPlane) UpdateInstance(ii *proto.Instance) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	i.init()
+	if prev, ok := i.instances[ii.ID]; !ok || prev.ModifyIndex != ii.ModifyIndex {
+		return ErrInstanceModified
 	}
+	i.upsertLocked(ii)
 	return nil
 }
 
+func (i *InmemControlPlane) upsertLocked(ii *proto.Instance) {
+	ii = ii.Copy()
+	ii.ModifyIndex = 1
+	if prev, ok := i.instances[ii.ID]; ok {
+		ii.ModifyIndex = prev.ModifyIndex + 1
+	}
+	i.instances[ii.ID] = ii
+	i.events.publish(ii.ID)
+}
+
 func (i *InmemControlPlane) GetInstance(InstanceID string) (*proto.Instance, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
 	ii, ok := i.instances[InstanceID]
 	if !ok {
 		return nil, nil
@@ -50,15 +89,21 @@ func (i *InmemControlPlane) GetInstance(InstanceID string) (*proto.Instance, err
 	return ii.Copy(), nil
 }
 
-func (i *InmemControlPlane) SubscribeInstanceUpdates() <-chan *InstanceUpdate {
+func (i *InmemControlPlane) SubscribeInstanceUpdates() *Subscription {
 	i.lock.Lock()
 	defer i.lock.Unlock()
 
-	if i.subs == nil {
-		i.subs = []chan *InstanceUpdate{}
-	}
-	ch := make(chan *InstanceUpdate, 10)
-	i.subs = append(i.subs, ch)
+	i.init()
+	return i.events.subscribe()
+}
 
-	return ch
+func (i *InmemControlPlane) instanceIDs() ([]string, error) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	ids := []string{}
+	for id := range i.instances {
+		ids = append(ids, id)
+	}
+	return ids, nil
 }
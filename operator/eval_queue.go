@@ -9,6 +9,30 @@ import (
 	"github.com/teseraio/ensemble/operator/proto"
 )
 
+// priority classes of the clusters. The class shifts the priority of all
+// the evaluations of the cluster.
+var priorityClasses = map[string]int64{
+	"low":    -10,
+	"normal": 0,
+	"high":   10,
+}
+
+// evalPriority returns the priority of an evaluation. The evaluations
+// triggered by the user go ahead of the internal ones and those go
+// ahead of the changes in the instances.
+func evalPriority(trigger proto.Evaluation_Trigger, class string) int64 {
+	var priority int64
+	switch trigger {
+	case proto.Evaluation_SPECCHANGE, proto.Evaluation_RESTART, proto.Evaluation_REPLACE, proto.Evaluation_RESUME:
+		priority = 30
+	case proto.Evaluation_NODECHANGE:
+		priority = 10
+	default:
+		priority = 20
+	}
+	return priority + priorityClasses[class]
+}
+
 type evalQueue struct {
 	heap     taskQueueImpl
 	lock     sync.Mutex
@@ -26,28 +50,66 @@ func newEvalQueue() *evalQueue {
 	}
 }
 
-func (e *evalQueue) add(eval *proto.Evaluation) {
+// add queues the evaluation. If there is an equivalent evaluation that has not
+// been processed yet, the evaluation is not queued, the coalesced count of the
+// other one is increased and it returns the id of the other one. Otherwise, store
+// (if set) is called with the evaluation before it can be popped from the queue.
+func (e *evalQueue) add(eval *proto.Evaluation, store func(eval *proto.Evaluation)) string {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	found := false
+	var current *evalTask
 	for _, i := range e.items {
 		if i.eval.DeploymentID == eval.DeploymentID {
-			found = true
+			current = i
 			break
 		}
 	}
-
-	if found {
-		// there is already a task for the same cluster, append
-		// this evaluation to the pending map
-		if _, ok := e.pending[eval.DeploymentID]; !ok {
-			e.pending = map[string][]*proto.Evaluation{}
+	if current == nil {
+		if store != nil {
+			store(eval)
 		}
-		e.pending[eval.DeploymentID] = append(e.pending[eval.DeploymentID], eval)
-	} else {
 		e.addImpl(eval)
+		return ""
+	}
+
+	// the evaluation is redundant if there is another one for the same
+	// cluster and trigger that has not been processed yet
+	if current.ready && sameEval(current.eval, eval) {
+		current.eval.Coalesced++
+		if eval.Priority > current.eval.Priority {
+			current.eval.Priority = eval.Priority
+			heap.Fix(&e.heap, current.index)
+		}
+		return current.eval.Id
+	}
+	for _, p := range e.pending[eval.DeploymentID] {
+		if sameEval(p, eval) {
+			p.Coalesced++
+			if eval.Priority > p.Priority {
+				p.Priority = eval.Priority
+			}
+			return p.Id
+		}
+	}
+
+	// there is already a task for the same cluster, append
+	// this evaluation to the pending map
+	if store != nil {
+		store(eval)
 	}
+	e.pending[eval.DeploymentID] = append(e.pending[eval.DeploymentID], eval)
+	return ""
+}
+
+// sameEval returns true if both evaluations have the same effect
+// so that only one of them has to be processed
+func sameEval(a, b *proto.Evaluation) bool {
+	return a.DeploymentID == b.DeploymentID &&
+		a.Type == b.Type &&
+		a.TriggeredBy == b.TriggeredBy &&
+		a.ComponentID == b.ComponentID &&
+		a.Sequence == b.Sequence
 }
 
 func (e *evalQueue) addImpl(eval *proto.Evaluation) {
@@ -109,11 +171,18 @@ func (e *evalQueue) finalize(id string) bool {
 	heap.Remove(&e.heap, i.index)
 	delete(e.items, id)
 
-	// check if there is a pending eval
+	// check if there is a pending eval, the one with the highest
+	// priority goes first
 	pending, ok := e.pending[i.eval.DeploymentID]
 	if ok {
-		var nextTask *proto.Evaluation
-		nextTask, pending = pending[0], pending[1:]
+		next := 0
+		for indx, p := range pending {
+			if p.Priority > pending[next].Priority {
+				next = indx
+			}
+		}
+		nextTask := pending[next]
+		pending = append(pending[:next], pending[next+1:]...)
 		if len(pending) == 0 {
 			delete(e.pending, i.eval.DeploymentID)
 		} else {
@@ -144,6 +213,9 @@ func (t taskQueueImpl) Less(i, j int) bool {
 	} else if jNoReady {
 		return true
 	}
+	if t[i].eval.Priority != t[j].eval.Priority {
+		return t[i].eval.Priority > t[j].eval.Priority
+	}
 	return t[i].timestamp.Before(t[j].timestamp)
 }
 
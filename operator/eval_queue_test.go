@@ -1,7 +1,147 @@
 package operator
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func popEvalTimeout(e *evalQueue) *proto.Evaluation {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	return e.pop(ctx)
+}
 
 func TestEvalQueue(t *testing.T) {
-	// TODO
+	e := newEvalQueue()
+
+	e.add(&proto.Evaluation{Id: "a1", DeploymentID: "a", TriggeredBy: proto.Evaluation_SPECCHANGE}, nil)
+	e.add(&proto.Evaluation{Id: "b1", DeploymentID: "b", TriggeredBy: proto.Evaluation_SPECCHANGE}, nil)
+
+	// only one evaluation per deployment at the same time
+	e.add(&proto.Evaluation{Id: "a2", DeploymentID: "a", TriggeredBy: proto.Evaluation_NODECHANGE}, nil)
+	e.add(&proto.Evaluation{Id: "b2", DeploymentID: "b", TriggeredBy: proto.Evaluation_NODECHANGE}, nil)
+
+	assert.Equal(t, popEvalTimeout(e).Id, "a1")
+	assert.Equal(t, popEvalTimeout(e).Id, "b1")
+	assert.Nil(t, popEvalTimeout(e))
+
+	// the pending evaluations of both deployments are kept
+	assert.True(t, e.finalize("a1"))
+	assert.True(t, e.finalize("b1"))
+
+	assert.Equal(t, popEvalTimeout(e).Id, "a2")
+	assert.Equal(t, popEvalTimeout(e).Id, "b2")
+	assert.Nil(t, popEvalTimeout(e))
+
+	assert.False(t, e.finalize("unknown"))
+}
+
+func TestEvalQueue_Coalesce(t *testing.T) {
+	e := newEvalQueue()
+
+	for i := 0; i < 10; i++ {
+		e.add(&proto.Evaluation{Id: evalID(i), DeploymentID: "a", TriggeredBy: proto.Evaluation_NODECHANGE}, nil)
+	}
+
+	// the evaluation is not processed yet so the others are redundant
+	eval := popEvalTimeout(e)
+	assert.Equal(t, eval.Id, evalID(0))
+	assert.Equal(t, eval.Coalesced, int64(9))
+	assert.True(t, e.finalize(eval.Id))
+	assert.Nil(t, popEvalTimeout(e))
+
+	e.add(&proto.Evaluation{Id: "x", DeploymentID: "a", TriggeredBy: proto.Evaluation_NODECHANGE}, nil)
+	eval = popEvalTimeout(e)
+	assert.Equal(t, eval.Id, "x")
+
+	// the node changes while the evaluation is in progress are not
+	// redundant with it but only one of them is kept
+	for i := 0; i < 10; i++ {
+		e.add(&proto.Evaluation{Id: evalID(i), DeploymentID: "a", TriggeredBy: proto.Evaluation_NODECHANGE}, nil)
+	}
+	// evaluations with a different trigger are not merged
+	e.add(&proto.Evaluation{Id: "y", DeploymentID: "a", TriggeredBy: proto.Evaluation_SPECCHANGE, Priority: 30}, nil)
+
+	assert.True(t, e.finalize("x"))
+
+	// the pending evaluation with more priority goes first
+	eval = popEvalTimeout(e)
+	assert.Equal(t, eval.Id, "y")
+	assert.True(t, e.finalize(eval.Id))
+
+	eval = popEvalTimeout(e)
+	assert.Equal(t, eval.Id, evalID(0))
+	assert.Equal(t, eval.Coalesced, int64(9))
+	assert.True(t, e.finalize(eval.Id))
+
+	assert.Nil(t, popEvalTimeout(e))
+}
+
+func TestEvalQueue_Priority(t *testing.T) {
+	e := newEvalQueue()
+
+	e.add(&proto.Evaluation{Id: "a", DeploymentID: "a", Priority: evalPriority(proto.Evaluation_NODECHANGE, "")}, nil)
+	e.add(&proto.Evaluation{Id: "b", DeploymentID: "b", Priority: evalPriority(proto.Evaluation_NODECHANGE, "high")}, nil)
+	e.add(&proto.Evaluation{Id: "c", DeploymentID: "c", Priority: evalPriority(proto.Evaluation_SPECCHANGE, "")}, nil)
+	e.add(&proto.Evaluation{Id: "d", DeploymentID: "d", Priority: evalPriority(proto.Evaluation_NODECHANGE, "")}, nil)
+
+	// a redundant evaluation with more priority raises the priority of the queued one
+	e.add(&proto.Evaluation{Id: "d2", DeploymentID: "d", Priority: evalPriority(proto.Evaluation_NODECHANGE, "high") + 1}, nil)
+
+	for _, id := range []string{"c", "d", "b", "a"} {
+		eval := popEvalTimeout(e)
+		if assert.NotNil(t, eval) {
+			assert.Equal(t, eval.Id, id)
+		}
+	}
+}
+
+func TestEvalPriority(t *testing.T) {
+	// user changes go ahead of the node changes of any class
+	assert.Greater(t, evalPriority(proto.Evaluation_SPECCHANGE, "low"), evalPriority(proto.Evaluation_NODECHANGE, "normal"))
+	assert.Greater(t, evalPriority(proto.Evaluation_SPECCHANGE, "normal"), evalPriority(proto.Evaluation_NODECHANGE, "high"))
+
+	// the priority class is honoured for the same trigger
+	assert.Greater(t, evalPriority(proto.Evaluation_NODECHANGE, "high"), evalPriority(proto.Evaluation_NODECHANGE, ""))
+	assert.Equal(t, evalPriority(proto.Evaluation_NODECHANGE, ""), evalPriority(proto.Evaluation_NODECHANGE, "normal"))
+	assert.Greater(t, evalPriority(proto.Evaluation_NODECHANGE, "normal"), evalPriority(proto.Evaluation_NODECHANGE, "low"))
+}
+
+func evalID(i int) string {
+	return fmt.Sprintf("eval-%d", i)
+}
+
+func TestServer_EvalPriorityClass(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:            "a",
+		PriorityClass: "high",
+	}))
+
+	eval := &proto.Evaluation{DeploymentID: "a", TriggeredBy: proto.Evaluation_NODECHANGE}
+	s.addEval(eval)
+	assert.Equal(t, eval.Priority, evalPriority(proto.Evaluation_NODECHANGE, "high"))
+
+	validate := func(class string) error {
+		_, err := s.validateComponent(&proto.Component{
+			Name: "b",
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				Groups: []*proto.ClusterSpec_Group{
+					{Type: "x", Count: 1},
+				},
+				PriorityClass: class,
+			}),
+		})
+		return err
+	}
+	assert.NoError(t, validate("low"))
+
+	// the priority class has to exist
+	assert.Error(t, validate("urgent"))
 }
@@ -0,0 +1,113 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// addEval queues an evaluation with the priority of its trigger and the
+// priority class of the deployment. The evaluation is stored in the state
+// for inspection unless it is redundant with another one in the queue.
+func (s *Server) addEval(eval *proto.Evaluation) {
+	var class string
+	if dep, err := s.State.LoadDeployment(eval.DeploymentID); err != nil {
+		s.logger.Error("failed to load deployment", "id", eval.DeploymentID, "err", err)
+	} else if dep != nil {
+		class = dep.PriorityClass
+	}
+	eval.Priority = evalPriority(eval.TriggeredBy, class)
+	eval.Status = proto.Evaluation_PENDING
+	eval.CreatedAt = ptypes.TimestampNow()
+
+	// the evaluation is stored before it is queued since the worker
+	// can pick it up right away
+	if id := s.evalQueue.add(eval, s.upsertEval); id != "" {
+		s.logger.Trace("eval coalesced", "id", eval.Id, "with", id, "cluster", eval.DeploymentID)
+	}
+}
+
+// startEval marks the evaluation as started by the worker
+func (s *Server) startEval(eval *proto.Evaluation) {
+	eval.StartedAt = ptypes.TimestampNow()
+	s.upsertEval(eval)
+}
+
+// finishEval stores the result of the evaluation and the plan (if any)
+func (s *Server) finishEval(eval *proto.Evaluation, status proto.Evaluation_Status, desc string, plan *proto.Plan, err error) {
+	eval.Status = status
+	eval.StatusDescription = desc
+	eval.CompletedAt = ptypes.TimestampNow()
+	if err != nil {
+		eval.Error = err.Error()
+	}
+	if plan != nil {
+		plan = plan.Copy()
+		plan.Deployment = nil
+		eval.Plan = plan
+	}
+	s.upsertEval(eval)
+}
+
+// cancelPendingEvals cancels the evaluations stored as pending. The queue of
+// evaluations is not persisted, the pending evaluations of a previous run
+// of the operator are never completed.
+func (s *Server) cancelPendingEvals() error {
+	evals, err := s.State.ListEvaluations("")
+	if err != nil {
+		return err
+	}
+	for _, eval := range evals {
+		if eval.Status != proto.Evaluation_PENDING {
+			continue
+		}
+		s.finishEval(eval, proto.Evaluation_CANCELLED, "operator restarted", nil, nil)
+	}
+	return nil
+}
+
+func (s *Server) upsertEval(eval *proto.Evaluation) {
+	if err := s.State.UpsertEvaluation(eval.Copy()); err != nil {
+		s.logger.Error("failed to store evaluation", "id", eval.Id, "err", err)
+	}
+}
+
+// ListEvaluations returns the evaluations of a deployment. If the
+// id is empty, it returns the evaluations of all the deployments.
+func (s *Server) ListEvaluations(deploymentID string) ([]*proto.Evaluation, error) {
+	return s.State.ListEvaluations(deploymentID)
+}
+
+// GetEvaluation returns an evaluation by id or by a unique prefix of the id
+func (s *Server) GetEvaluation(id string) (*proto.Evaluation, error) {
+	if id == "" {
+		return nil, fmt.Errorf("evaluation id is empty")
+	}
+	eval, err := s.State.GetEvaluation(id)
+	if err != nil {
+		return nil, err
+	}
+	if eval != nil {
+		return eval, nil
+	}
+
+	evals, err := s.State.ListEvaluations("")
+	if err != nil {
+		return nil, err
+	}
+	matches := []*proto.Evaluation{}
+	for _, eval := range evals {
+		if strings.HasPrefix(eval.Id, id) {
+			matches = append(matches, eval)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("evaluation '%s' not found", id)
+	}
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("prefix '%s' matches %d evaluations", id, len(matches))
+	}
+	return matches[0], nil
+}
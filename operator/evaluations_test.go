@@ -0,0 +1,119 @@
+package operator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestServer_Evaluations(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	eval1 := &proto.Evaluation{Id: "eval-1", DeploymentID: "dep1", TriggeredBy: proto.Evaluation_NODECHANGE}
+	s.addEval(eval1)
+
+	// the redundant evaluations are not stored, they are
+	// counted in the evaluation that is queued
+	for i := 0; i < 3; i++ {
+		s.addEval(&proto.Evaluation{Id: fmt.Sprintf("redundant-%d", i), DeploymentID: "dep1", TriggeredBy: proto.Evaluation_NODECHANGE})
+	}
+
+	evals, err := s.ListEvaluations("dep1")
+	assert.NoError(t, err)
+	if assert.Len(t, evals, 1) {
+		assert.Equal(t, evals[0].Status, proto.Evaluation_PENDING)
+		assert.NotNil(t, evals[0].CreatedAt)
+	}
+
+	// the count is stored once the evaluation starts
+	s.startEval(eval1)
+
+	eval, err := s.GetEvaluation("eval-1")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Coalesced, int64(3))
+
+	// an evaluation that is not redundant is stored as pending
+	s.addEval(&proto.Evaluation{Id: "eval-2", DeploymentID: "dep1", TriggeredBy: proto.Evaluation_SPECCHANGE})
+
+	eval, err = s.GetEvaluation("eval-2")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Status, proto.Evaluation_PENDING)
+
+	// the plan is stored without the deployment
+	s.finishEval(eval1, proto.Evaluation_FAILED, "", &proto.Plan{
+		Deployment: &proto.Deployment{Id: "dep1"},
+		NodeUpdate: []*proto.Instance{
+			{ID: "i0"},
+		},
+	}, fmt.Errorf("bad"))
+
+	eval, err = s.GetEvaluation("eval-1")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Status, proto.Evaluation_FAILED)
+	assert.Equal(t, eval.Error, "bad")
+	assert.NotNil(t, eval.StartedAt)
+	assert.NotNil(t, eval.CompletedAt)
+	assert.Nil(t, eval.Plan.Deployment)
+	assert.Len(t, eval.Plan.NodeUpdate, 1)
+
+	// an evaluation can be found by a unique prefix
+	_, err = s.GetEvaluation("eval-")
+	assert.Error(t, err)
+
+	eval, err = s.GetEvaluation("eval-2")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Id, "eval-2")
+
+	_, err = s.GetEvaluation("unknown")
+	assert.Error(t, err)
+
+	evals, err = s.ListEvaluations("dep2")
+	assert.NoError(t, err)
+	assert.Len(t, evals, 0)
+}
+
+func TestServer_CancelPendingEvals(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	// one evaluation is queued and the other one is running
+	// when the operator stops
+	queued := &proto.Evaluation{Id: "queued", DeploymentID: "dep1", TriggeredBy: proto.Evaluation_SPECCHANGE}
+	s.addEval(queued)
+
+	running := &proto.Evaluation{Id: "running", DeploymentID: "dep1", TriggeredBy: proto.Evaluation_NODECHANGE}
+	s.addEval(running)
+	s.startEval(running)
+
+	completed := &proto.Evaluation{Id: "completed", DeploymentID: "dep1"}
+	s.addEval(completed)
+	s.finishEval(completed, proto.Evaluation_COMPLETE, "", nil, nil)
+
+	// the operator restarts with the same state
+	s2 := testServer(t, &nullHandler{})
+	s2.State = s.State
+	s = s2
+
+	assert.NoError(t, s.cancelPendingEvals())
+
+	for _, id := range []string{"queued", "running"} {
+		eval, err := s.GetEvaluation(id)
+		assert.NoError(t, err)
+		assert.Equal(t, eval.Status, proto.Evaluation_CANCELLED)
+		assert.Equal(t, eval.StatusDescription, "operator restarted")
+		assert.NotNil(t, eval.CompletedAt)
+	}
+
+	eval, err := s.GetEvaluation("completed")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Status, proto.Evaluation_COMPLETE)
+}
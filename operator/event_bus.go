@@ -0,0 +1,219 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultEventBufferSize is the number of updates kept in the event bus
+// for the subscribers that are behind
+const defaultEventBufferSize = 1024
+
+// ErrSubscriptionClosed is returned by Next once the subscription
+// or the event bus is closed
+var ErrSubscriptionClosed = fmt.Errorf("subscription closed")
+
+// InstanceUpdate notifies that an instance changed in the control plane
+type InstanceUpdate struct {
+	InstanceID string
+
+	// Index is the position of the update in the event bus
+	Index uint64
+}
+
+// eventBus is an ordered log of instance updates. Each update has an increasing
+// index and the subscribers pull them at their own pace, a publisher never blocks
+// nor drops an update because of a slow subscriber. Only the latest updates are
+// buffered, a subscriber that falls further behind catches up with the list
+// of the current instances returned by catchUp.
+type eventBus struct {
+	lock     sync.Mutex
+	index    uint64
+	buf      []*InstanceUpdate
+	size     int
+	updateCh chan struct{}
+	closeCh  chan struct{}
+	closed   bool
+
+	// catchUp returns the ids of all the instances in the control plane
+	catchUp func() ([]string, error)
+}
+
+func newEventBus(size int, catchUp func() ([]string, error)) *eventBus {
+	return &eventBus{
+		buf:      []*InstanceUpdate{},
+		size:     size,
+		updateCh: make(chan struct{}),
+		closeCh:  make(chan struct{}),
+		catchUp:  catchUp,
+	}
+}
+
+// publish appends an update for the instance and wakes up the subscribers
+func (e *eventBus) publish(instanceID string) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if e.closed {
+		return
+	}
+	e.index++
+	e.buf = append(e.buf, &InstanceUpdate{
+		InstanceID: instanceID,
+		Index:      e.index,
+	})
+	if len(e.buf) > e.size {
+		e.buf = e.buf[len(e.buf)-e.size:]
+	}
+
+	close(e.updateCh)
+	e.updateCh = make(chan struct{})
+}
+
+// subscribe returns a subscription that receives the updates published
+// from now on
+func (e *eventBus) subscribe() *Subscription {
+	return e.subscribeFunc(nil)
+}
+
+// subscribeFunc returns a subscription that only receives the updates
+// of the instances accepted by the filter
+func (e *eventBus) subscribeFunc(filter func(instanceID string) bool) *Subscription {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return &Subscription{
+		bus:     e,
+		index:   e.index,
+		filter:  filter,
+		closeCh: make(chan struct{}),
+	}
+}
+
+// close wakes up the subscribers and stops the bus
+func (e *eventBus) close() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.closed {
+		e.closed = true
+		close(e.closeCh)
+	}
+}
+
+// Subscription tracks the index of the last update received by a subscriber.
+// Next must not be called concurrently on the same subscription.
+type Subscription struct {
+	bus       *eventBus
+	index     uint64
+	pending   []*InstanceUpdate
+	filter    func(instanceID string) bool
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// Index returns the index of the last update received
+func (s *Subscription) Index() uint64 {
+	return s.index
+}
+
+// Instances returns the ids of all the instances in the control plane
+// accepted by the filter of the subscription when the subscriber needs
+// to handle the instances that existed before the subscription started
+func (s *Subscription) Instances() ([]string, error) {
+	ids, err := s.bus.catchUp()
+	if err != nil {
+		return nil, err
+	}
+	if s.filter == nil {
+		return ids, nil
+	}
+	res := []string{}
+	for _, id := range ids {
+		if s.filter(id) {
+			res = append(res, id)
+		}
+	}
+	return res, nil
+}
+
+// Next returns the next update of the subscription. It blocks until there is
+// a new update, the context is done or the subscription is closed.
+func (s *Subscription) Next(ctx context.Context) (*InstanceUpdate, error) {
+	for {
+		select {
+		case <-s.closeCh:
+			return nil, ErrSubscriptionClosed
+		default:
+		}
+
+		if len(s.pending) != 0 {
+			update := s.pending[0]
+			s.pending = s.pending[1:]
+			if !s.accept(update) {
+				continue
+			}
+			return update, nil
+		}
+
+		e := s.bus
+		e.lock.Lock()
+		if e.closed {
+			e.lock.Unlock()
+			return nil, ErrSubscriptionClosed
+		}
+		if s.index < e.index {
+			first := e.index - uint64(len(e.buf)) + 1
+			if s.index+1 >= first {
+				update := e.buf[s.index+1-first]
+				s.index = update.Index
+				e.lock.Unlock()
+				if !s.accept(update) {
+					continue
+				}
+				return update, nil
+			}
+
+			// the updates after the index of the subscription are not
+			// buffered anymore, catch up with the current instances
+			index := e.index
+			e.lock.Unlock()
+
+			ids, err := e.catchUp()
+			if err != nil {
+				return nil, fmt.Errorf("failed to catch up with the instances: %v", err)
+			}
+			for _, id := range ids {
+				s.pending = append(s.pending, &InstanceUpdate{
+					InstanceID: id,
+					Index:      index,
+				})
+			}
+			s.index = index
+			continue
+		}
+		updateCh := e.updateCh
+		e.lock.Unlock()
+
+		select {
+		case <-updateCh:
+		case <-e.closeCh:
+		case <-s.closeCh:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// accept returns true if the update passes the filter of the subscription
+func (s *Subscription) accept(update *InstanceUpdate) bool {
+	return s.filter == nil || s.filter(update.InstanceID)
+}
+
+// Close unsubscribes from the event bus and unblocks any call to Next
+func (s *Subscription) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closeCh)
+	})
+}
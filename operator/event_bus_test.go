@@ -0,0 +1,202 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func nextUpdate(t *testing.T, sub *Subscription) *InstanceUpdate {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	update, err := sub.Next(ctx)
+	assert.NoError(t, err)
+	return update
+}
+
+func TestEventBus_Ordered(t *testing.T) {
+	e := newEventBus(10, nil)
+
+	sub := e.subscribe()
+	defer sub.Close()
+
+	for i := 0; i < 3; i++ {
+		e.publish(fmt.Sprintf("i%d", i))
+	}
+	for i := 0; i < 3; i++ {
+		update := nextUpdate(t, sub)
+		assert.Equal(t, update.InstanceID, fmt.Sprintf("i%d", i))
+		assert.Equal(t, update.Index, uint64(i+1))
+	}
+	assert.Equal(t, sub.Index(), uint64(3))
+
+	// a new subscription only receives the new updates
+	sub2 := e.subscribe()
+	defer sub2.Close()
+
+	e.publish("i3")
+	assert.Equal(t, nextUpdate(t, sub2).InstanceID, "i3")
+	assert.Equal(t, nextUpdate(t, sub).InstanceID, "i3")
+}
+
+func TestEventBus_Block(t *testing.T) {
+	e := newEventBus(10, nil)
+
+	sub := e.subscribe()
+
+	// it blocks until there is an update
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := sub.Next(ctx)
+	assert.Equal(t, err, context.DeadlineExceeded)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		e.publish("i0")
+	}()
+	assert.Equal(t, nextUpdate(t, sub).InstanceID, "i0")
+
+	// close unblocks the subscriber
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		sub.Close()
+	}()
+	_, err = sub.Next(context.Background())
+	assert.Equal(t, err, ErrSubscriptionClosed)
+
+	// the updates are not delivered after the bus is closed
+	sub2 := e.subscribe()
+	e.close()
+	e.publish("i1")
+
+	_, err = sub2.Next(context.Background())
+	assert.Equal(t, err, ErrSubscriptionClosed)
+}
+
+func TestEventBus_SlowConsumerCatchUp(t *testing.T) {
+	e := newEventBus(4, func() ([]string, error) {
+		return []string{"a", "b"}, nil
+	})
+
+	sub := e.subscribe()
+	defer sub.Close()
+
+	// the subscriber falls behind the buffered updates
+	for i := 0; i < 10; i++ {
+		e.publish(fmt.Sprintf("i%d", i))
+	}
+
+	// catch up with the current instances
+	for _, id := range []string{"a", "b"} {
+		update := nextUpdate(t, sub)
+		assert.Equal(t, update.InstanceID, id)
+		assert.Equal(t, update.Index, uint64(10))
+	}
+
+	e.publish("i10")
+	update := nextUpdate(t, sub)
+	assert.Equal(t, update.InstanceID, "i10")
+	assert.Equal(t, update.Index, uint64(11))
+}
+
+func TestEventBus_SlowConsumer(t *testing.T) {
+	e := newEventBus(defaultEventBufferSize, nil)
+
+	sub := e.subscribe()
+	defer sub.Close()
+
+	num := 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < num; j++ {
+				e.publish(fmt.Sprintf("i%d-%d", i, j))
+			}
+		}(i)
+	}
+
+	// no update is lost even if the subscriber is slower than the publishers
+	found := map[string]struct{}{}
+	for i := 0; i < 4*num; i++ {
+		update := nextUpdate(t, sub)
+		if update == nil {
+			t.Fatal("update not found")
+		}
+		assert.Equal(t, update.Index, uint64(i+1))
+		found[update.InstanceID] = struct{}{}
+
+		if i%50 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	assert.Len(t, found, 4*num)
+	wg.Wait()
+}
+
+func TestInmemControlPlane_SlowConsumer(t *testing.T) {
+	c := &InmemControlPlane{}
+
+	sub := c.SubscribeInstanceUpdates()
+	defer sub.Close()
+
+	num := defaultEventBufferSize + 10
+	for i := 0; i < num; i++ {
+		assert.NoError(t, c.UpsertInstance(&proto.Instance{
+			ID: fmt.Sprintf("i%d", i),
+		}))
+	}
+
+	// the subscriber catches up with all the instances in the control plane
+	ids := []string{}
+	for i := 0; i < num; i++ {
+		ids = append(ids, nextUpdate(t, sub).InstanceID)
+	}
+	sort.Strings(ids)
+	for i := 1; i < len(ids); i++ {
+		assert.NotEqual(t, ids[i-1], ids[i])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := sub.Next(ctx)
+	assert.Error(t, err)
+}
+
+func TestServer_SubscriptionCatchUp(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+	s.events = newEventBus(2, s.instanceIDs)
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	sub := s.SubscribeInstanceUpdates()
+	defer sub.Close()
+
+	for _, id := range []string{"i0", "i1", "i2"} {
+		assert.NoError(t, s.UpsertInstance(&proto.Instance{
+			ID:           id,
+			DeploymentID: "dep1",
+		}))
+	}
+
+	// the updates are read again from the state
+	ids := []string{}
+	for i := 0; i < 3; i++ {
+		ids = append(ids, nextUpdate(t, sub).InstanceID)
+	}
+	sort.Strings(ids)
+	assert.Equal(t, ids, []string{"i0", "i1", "i2"})
+}
@@ -16,6 +16,10 @@ type Harness struct {
 	Handler    Handler
 	Scheduler  Scheduler
 	Component  *proto.Component
+
+	// Outputs are the outputs of other clusters referenced
+	// by the component (i.e. zookeeper.main.connect)
+	Outputs map[string]string
 }
 
 func NewHarness(t assert.TestingT) *Harness {
@@ -29,6 +33,14 @@ func (h *Harness) AddComponent(c *proto.Component) {
 	if c.Id == "" {
 		c.Id = uuid.UUID()
 	}
+	if h.Handler != nil {
+		// evaluate the component like the server does before applying it
+		var err error
+		if c, err = h.Handler.Evaluate(c); err != nil {
+			assert.NoError(h.t, err)
+			return
+		}
+	}
 	h.Component = c
 	h.Deployment.CompId = c.Id
 }
@@ -72,6 +84,14 @@ func (h *Harness) GetHandler(id string) (Handler, error) {
 	return h.Handler, nil
 }
 
+func (h *Harness) ResolveOutput(ref *Reference) (string, error) {
+	value, ok := h.Outputs[ref.Backend+"."+ref.Cluster+"."+ref.Output]
+	if !ok {
+		return "", fmt.Errorf("output %s not found", ref)
+	}
+	return value, nil
+}
+
 func (h *Harness) Eval() *proto.Plan {
 	plan, err := h.Scheduler.Process(&proto.Evaluation{})
 	assert.NoError(h.t, err)
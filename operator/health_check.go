@@ -0,0 +1,164 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+const (
+	defaultHealthCheckInterval  = 10 * time.Second
+	defaultHealthCheckTimeout   = 5 * time.Second
+	defaultHealthCheckThreshold = 3
+)
+
+// HealthCheck is a periodic check of the health of the instances. The
+// instance is checked with all the methods that are set.
+type HealthCheck struct {
+	// TCP is a port of the instance that has to accept connections
+	TCP uint64
+
+	// HTTP is a request to the instance that has to return a 2xx code
+	HTTP *HTTPCheck
+
+	// Exec is a command that has to run successfully in the instance. The
+	// control plane has to implement the Executor interface.
+	Exec []string
+
+	// Client is a check done with the client of the backend
+	Client func(ctx context.Context, clt interface{}, i *proto.Instance) error
+
+	// Interval is the time between two checks
+	Interval time.Duration
+
+	// Timeout is the maximum duration of a check
+	Timeout time.Duration
+
+	// FailureThreshold is the number of consecutive failed checks
+	// before the instance is marked as unhealthy
+	FailureThreshold int
+}
+
+// HTTPCheck is an HTTP GET request to an instance
+type HTTPCheck struct {
+	Port uint64
+	Path string
+}
+
+// Executor is an optional interface for the control planes
+// that can run commands in the instances
+type Executor interface {
+	Exec(n *proto.Instance, path string, cmd ...string) (string, error)
+}
+
+// startHealthCheck runs the health check of the instance in the background
+// until the instance stops
+func (b *BaseOperator) startHealthCheck(ctx context.Context, instance *proto.Instance, check *HealthCheck) {
+	id := instance.ID
+
+	b.startTask(ctx, taskHealthCheck, id, func(ctx context.Context) {
+		b.runHealthCheck(ctx, id, check)
+	})
+}
+
+func (b *BaseOperator) runHealthCheck(ctx context.Context, id string, check *HealthCheck) {
+	interval := check.Interval
+	if interval == 0 {
+		interval = defaultHealthCheckInterval
+	}
+	timeout := check.Timeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	threshold := check.FailureThreshold
+	if threshold == 0 {
+		threshold = defaultHealthCheckThreshold
+	}
+
+	failures := 0
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+
+		instance, err := b.cplane.GetInstance(id)
+		if err != nil || instance == nil {
+			return
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = b.checkHealth(checkCtx, check, instance.Copy())
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		var update bool
+		if err == nil {
+			// the instance recovers after a successful check
+			failures = 0
+			update = !instance.Healthy
+		} else {
+			failures++
+			update = instance.Healthy && failures >= threshold
+			err = fmt.Errorf("health check failed: %v", err)
+		}
+		if update {
+			if err := b.setHealth(id, err); err != nil {
+				b.logger.Error("failed to update the health of the instance", "id", id, "err", err)
+			}
+		}
+	}
+}
+
+func (b *BaseOperator) checkHealth(ctx context.Context, check *HealthCheck, instance *proto.Instance) error {
+	if check.TCP != 0 {
+		var d net.Dialer
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(instance.Ip, strconv.Itoa(int(check.TCP))))
+		if err != nil {
+			return err
+		}
+		conn.Close()
+	}
+
+	if check.HTTP != nil {
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(instance.Ip, strconv.Itoa(int(check.HTTP.Port))), check.HTTP.Path)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+	}
+
+	if len(check.Exec) != 0 {
+		if _, err := b.exec(ctx, instance, check.Exec[0], check.Exec[1:]...); err != nil {
+			return err
+		}
+	}
+
+	if check.Client != nil {
+		clt, err := b.handler.Client(instance)
+		if err != nil {
+			return err
+		}
+		if err := check.Client(ctx, clt, instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
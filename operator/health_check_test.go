@@ -0,0 +1,243 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestBaseOperator_HealthCheck(t *testing.T) {
+	var failing int32
+
+	b := newMockHandler(&Spec{
+		HealthCheck: &HealthCheck{
+			Client: func(ctx context.Context, clt interface{}, i *proto.Instance) error {
+				if atomic.LoadInt32(&failing) == 1 {
+					return fmt.Errorf("partitioned")
+				}
+				return nil
+			},
+			Interval:         5 * time.Millisecond,
+			FailureThreshold: 2,
+		},
+	})
+
+	c := &InmemControlPlane{}
+	b.Setup(c)
+
+	assert.NoError(t, c.UpsertInstance(&proto.Instance{
+		ID:      "a",
+		Status:  proto.Instance_RUNNING,
+		Healthy: true,
+	}))
+
+	// the instance is unhealthy after the failed checks
+	atomic.StoreInt32(&failing, 1)
+
+	i := waitForInstance(t, c, "a", func(i *proto.Instance) bool {
+		return !i.Healthy
+	})
+	assert.Equal(t, i.HealthError, "health check failed: partitioned")
+	assert.NotNil(t, i.UnhealthySince)
+
+	// and it recovers once the check succeeds again
+	atomic.StoreInt32(&failing, 0)
+
+	i = waitForInstance(t, c, "a", func(i *proto.Instance) bool {
+		return i.Healthy
+	})
+	assert.Empty(t, i.HealthError)
+	assert.Nil(t, i.UnhealthySince)
+}
+
+func TestBaseOperator_HealthCheckOwnInstances(t *testing.T) {
+	// checked records the instances checked by each backend
+	var lock sync.Mutex
+	checked := map[string][]string{}
+
+	newHandler := func(name string, checkErr error) *mockHandler {
+		h := newMockHandler(&Spec{
+			HealthCheck: &HealthCheck{
+				Client: func(ctx context.Context, clt interface{}, i *proto.Instance) error {
+					lock.Lock()
+					checked[name] = append(checked[name], i.ID)
+					lock.Unlock()
+					return checkErr
+				},
+				Interval:         5 * time.Millisecond,
+				FailureThreshold: 1,
+			},
+		})
+		h.name = name
+		return h
+	}
+
+	a := newHandler("a", fmt.Errorf("partitioned"))
+	b := newHandler("b", nil)
+
+	s := testServer(t, a)
+	s.handlers = map[string]Handler{
+		"a": a,
+		"b": b,
+	}
+	defer s.events.close()
+
+	a.Setup(s.backendControlPlane("a"))
+	b.Setup(s.backendControlPlane("b"))
+
+	for _, name := range []string{"a", "b"} {
+		assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+			Id:      "dep-" + name,
+			Backend: name,
+		}))
+		assert.NoError(t, s.UpsertInstance(&proto.Instance{
+			ID:           "i-" + name,
+			DeploymentID: "dep-" + name,
+			Status:       proto.Instance_RUNNING,
+			Healthy:      true,
+		}))
+	}
+
+	// the failing check of the backend a only marks its own instance
+	waitForInstance(t, s, "i-a", func(i *proto.Instance) bool {
+		return !i.Healthy
+	})
+	waitForInstance(t, s, "i-b", func(i *proto.Instance) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(checked["b"]) != 0
+	})
+
+	ib, err := s.GetInstance("i-b")
+	assert.NoError(t, err)
+	assert.True(t, ib.Healthy)
+
+	lock.Lock()
+	defer lock.Unlock()
+	for name, ids := range checked {
+		for _, id := range ids {
+			assert.Equal(t, id, "i-"+name)
+		}
+	}
+}
+
+func TestHealthCheck_Methods(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	assert.NoError(t, err)
+
+	b := newMockHandler(&Spec{})
+	b.cplane = &InmemControlPlane{}
+
+	check := func(c *HealthCheck) error {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		return b.checkHealth(ctx, c, &proto.Instance{Ip: host})
+	}
+
+	assert.NoError(t, check(&HealthCheck{TCP: uint64(port)}))
+	assert.NoError(t, check(&HealthCheck{HTTP: &HTTPCheck{Port: uint64(port), Path: "/health"}}))
+	assert.Error(t, check(&HealthCheck{HTTP: &HTTPCheck{Port: uint64(port), Path: "/other"}}))
+
+	// the inmem control plane cannot run commands
+	assert.Error(t, check(&HealthCheck{Exec: []string{"true"}}))
+}
+
+// interleavedControlPlane runs a write after the instance is read
+type interleavedControlPlane struct {
+	*Server
+
+	write func()
+}
+
+func (c *interleavedControlPlane) GetInstance(id string) (*proto.Instance, error) {
+	instance, err := c.Server.GetInstance(id)
+	if write := c.write; write != nil {
+		c.write = nil
+		write()
+	}
+	return instance, err
+}
+
+func TestBaseOperator_SetHealthConflict(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:     "dep1",
+		Status: proto.DeploymentRunning,
+	}))
+	for _, id := range []string{"i0", "i1"} {
+		assert.NoError(t, s.UpsertInstance(&proto.Instance{
+			ID:           id,
+			DeploymentID: "dep1",
+			Status:       proto.Instance_RUNNING,
+			Healthy:      true,
+		}))
+	}
+
+	cplane := &interleavedControlPlane{Server: s}
+	b := &BaseOperator{
+		cplane: cplane,
+	}
+
+	// applyPlan applies a plan that updates the instance while its health is updated
+	applyPlan := func(id string, update func(i *proto.Instance)) func() {
+		return func() {
+			dep, err := s.State.LoadDeployment("dep1")
+			assert.NoError(t, err)
+
+			i, err := s.GetInstance(id)
+			assert.NoError(t, err)
+			update(i)
+
+			plan := &proto.Plan{
+				Deployment: dep,
+				Status:     proto.DeploymentRunning,
+				NodeUpdate: []*proto.Instance{i},
+			}
+			assert.NoError(t, s.SubmitPlan(&proto.Evaluation{DeploymentID: "dep1"}, plan))
+			assert.Empty(t, plan.Rejected)
+		}
+	}
+
+	// the instance is stopped by the plan, the health is not updated
+	cplane.write = applyPlan("i0", func(i *proto.Instance) {
+		i.DesiredStatus = proto.Instance_STOP
+	})
+	assert.NoError(t, b.setHealth("i0", fmt.Errorf("partitioned")))
+
+	i0, err := s.GetInstance("i0")
+	assert.NoError(t, err)
+	assert.Equal(t, i0.DesiredStatus, proto.Instance_STOP)
+	assert.True(t, i0.Healthy)
+
+	// the health is updated on top of the changes of the plan
+	cplane.write = applyPlan("i1", func(i *proto.Instance) {
+		i.Replace = true
+	})
+	assert.NoError(t, b.setHealth("i1", fmt.Errorf("partitioned")))
+
+	i1, err := s.GetInstance("i1")
+	assert.NoError(t, err)
+	assert.True(t, i1.Replace)
+	assert.False(t, i1.Healthy)
+	assert.Equal(t, i1.HealthError, "partitioned")
+}
@@ -0,0 +1,314 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/teseraio/ensemble/lib/uuid"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// OutputType is the type of the value published by a backend
+type OutputType string
+
+const (
+	// OutputEndpoint is a list of comma separated host:port addresses
+	OutputEndpoint OutputType = "endpoint"
+
+	// OutputPort is a port number
+	OutputPort OutputType = "port"
+
+	// OutputString is any other value
+	OutputString OutputType = "string"
+
+	// OutputSecret is a credential. If the output does not have a Value
+	// function, a random value is generated once and kept for the deployment.
+	OutputSecret OutputType = "secret"
+)
+
+// Output is a value of a deployment that other clusters can reference
+type Output struct {
+	Type        OutputType
+	Description string
+
+	// Value computes the output from the deployment
+	Value func(dep *proto.Deployment) (string, error)
+}
+
+// OutputsHandler is an optional interface for the handlers that publish
+// outputs for their deployments
+type OutputsHandler interface {
+	// GetOutputs returns the outputs published by the backend
+	GetOutputs() map[string]*Output
+
+	// Outputs computes the outputs of the deployment
+	Outputs(dep *proto.Deployment) (map[string]string, error)
+}
+
+// GetOutputs implements the OutputsHandler interface
+func (b *BaseOperator) GetOutputs() map[string]*Output {
+	return b.handler.Spec().Outputs
+}
+
+// Outputs implements the OutputsHandler interface
+func (b *BaseOperator) Outputs(dep *proto.Deployment) (map[string]string, error) {
+	res := map[string]string{}
+	for name, output := range b.handler.Spec().Outputs {
+		if output.Value == nil {
+			if output.Type != OutputSecret {
+				return nil, fmt.Errorf("output '%s' does not have a value", name)
+			}
+			// generate the secret only once
+			value, ok := dep.Outputs[name]
+			if !ok {
+				value = uuid.UUID()
+			}
+			res[name] = value
+			continue
+		}
+		value, err := output.Value(dep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute output '%s': %v", name, err)
+		}
+		res[name] = value
+	}
+	return res, nil
+}
+
+// RunningEndpoints returns the sorted addresses of the running instances
+// of the deployment on the given port
+func RunningEndpoints(dep *proto.Deployment, port uint64) string {
+	res := []string{}
+	for _, i := range dep.Instances {
+		if i.Status != proto.Instance_RUNNING {
+			continue
+		}
+		res = append(res, fmt.Sprintf("%s:%d", i.FullName(), port))
+	}
+	sort.Strings(res)
+	return strings.Join(res, ",")
+}
+
+// referenceRegexp matches the references to the outputs of other
+// clusters with the format ${backend.cluster.output}
+var referenceRegexp = regexp.MustCompile(`\$\{([^.{}]+)\.([^{}]+)\.([^.{}]+)\}`)
+
+// Reference is a reference to an output of another cluster
+type Reference struct {
+	Backend string
+	Cluster string
+	Output  string
+}
+
+func (r *Reference) String() string {
+	return fmt.Sprintf("${%s.%s.%s}", r.Backend, r.Cluster, r.Output)
+}
+
+// IsReference returns true if the value has a reference to an output
+func IsReference(value string) bool {
+	return referenceRegexp.MatchString(value)
+}
+
+// specReferences returns the references to outputs in the params of the groups
+func specReferences(spec *proto.ClusterSpec) []*Reference {
+	refs := []*Reference{}
+	for _, grp := range spec.Groups {
+		walkLiterals(grp.Params, func(value string) {
+			for _, match := range referenceRegexp.FindAllStringSubmatch(value, -1) {
+				refs = append(refs, &Reference{
+					Backend: match[1],
+					Cluster: match[2],
+					Output:  match[3],
+				})
+			}
+		})
+	}
+	return refs
+}
+
+func walkLiterals(spec *proto.Spec, fn func(value string)) {
+	if spec == nil {
+		return
+	}
+	switch obj := spec.Block.(type) {
+	case *proto.Spec_Literal_:
+		fn(obj.Literal.Value)
+	case *proto.Spec_BlockValue:
+		for _, attr := range obj.BlockValue.Attrs {
+			walkLiterals(attr, fn)
+		}
+	case *proto.Spec_Array_:
+		for _, value := range obj.Array.Values {
+			walkLiterals(value, fn)
+		}
+	}
+}
+
+// resolveReferences replaces the references in the params of the groups
+// with the value returned by the resolver
+func resolveReferences(spec *proto.ClusterSpec, resolve func(ref *Reference) (string, error)) error {
+	for _, grp := range spec.Groups {
+		if grp.Params == nil {
+			continue
+		}
+		params := gproto.Clone(grp.Params).(*proto.Spec)
+
+		var err error
+		replaceLiterals(params, func(value string) string {
+			return referenceRegexp.ReplaceAllStringFunc(value, func(str string) string {
+				match := referenceRegexp.FindStringSubmatch(str)
+				res, resErr := resolve(&Reference{
+					Backend: match[1],
+					Cluster: match[2],
+					Output:  match[3],
+				})
+				if resErr != nil && err == nil {
+					err = resErr
+				}
+				return res
+			})
+		})
+		if err != nil {
+			return err
+		}
+		grp.Params = params
+	}
+	return nil
+}
+
+func replaceLiterals(spec *proto.Spec, fn func(value string) string) {
+	if spec == nil {
+		return
+	}
+	switch obj := spec.Block.(type) {
+	case *proto.Spec_Literal_:
+		obj.Literal.Value = fn(obj.Literal.Value)
+	case *proto.Spec_BlockValue:
+		for _, attr := range obj.BlockValue.Attrs {
+			replaceLiterals(attr, fn)
+		}
+	case *proto.Spec_Array_:
+		for _, value := range obj.Array.Values {
+			replaceLiterals(value, fn)
+		}
+	}
+}
+
+// validateReferences checks that the references in the spec are outputs
+// published by the backends and adds the referenced clusters as dependencies
+func (s *Server) validateReferences(spec *proto.ClusterSpec) error {
+	for _, ref := range specReferences(spec) {
+		handler, err := s.GetHandler(ref.Backend)
+		if err != nil {
+			return fmt.Errorf("reference %s: backend '%s' not found", ref, ref.Backend)
+		}
+		outputsHandler, ok := handler.(OutputsHandler)
+		if !ok {
+			return fmt.Errorf("reference %s: backend '%s' does not have outputs", ref, ref.Backend)
+		}
+		if _, ok := outputsHandler.GetOutputs()[ref.Output]; !ok {
+			return fmt.Errorf("reference %s: output '%s' not found", ref, ref.Output)
+		}
+
+		found := false
+		for _, name := range spec.DependsOn {
+			if name == ref.Cluster {
+				found = true
+			}
+		}
+		if !found {
+			spec.DependsOn = append(spec.DependsOn, ref.Cluster)
+		}
+	}
+	return nil
+}
+
+// ResolveOutput returns the value of an output published by another cluster
+func (s *Server) ResolveOutput(ref *Reference) (string, error) {
+	depID, err := s.State.NameToDeployment(ref.Cluster)
+	if err != nil {
+		return "", err
+	}
+	if depID == "" {
+		return "", fmt.Errorf("reference %s: cluster '%s' not found", ref, ref.Cluster)
+	}
+	dep, err := s.State.LoadDeployment(depID)
+	if err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(dep.Backend, ref.Backend) {
+		return "", fmt.Errorf("reference %s: cluster '%s' has backend '%s'", ref, ref.Cluster, dep.Backend)
+	}
+	value, ok := dep.Outputs[ref.Output]
+	if !ok {
+		return "", fmt.Errorf("reference %s: output not published yet", ref)
+	}
+	return value, nil
+}
+
+// publishOutputs computes the outputs of a deployment and returns
+// whether they changed
+func (s *Server) publishOutputs(dep *proto.Deployment) (bool, error) {
+	handler, err := s.GetHandler(dep.Backend)
+	if err != nil {
+		return false, err
+	}
+	outputsHandler, ok := handler.(OutputsHandler)
+	if !ok {
+		return false, nil
+	}
+	outputs, err := outputsHandler.Outputs(dep)
+	if err != nil {
+		return false, err
+	}
+	if len(outputs) == len(dep.Outputs) {
+		changed := false
+		for k, v := range outputs {
+			if prev, ok := dep.Outputs[k]; !ok || prev != v {
+				changed = true
+			}
+		}
+		if !changed {
+			return false, nil
+		}
+	}
+	dep.Outputs = outputs
+	return true, nil
+}
+
+// evalDependents creates an evaluation for the clusters that depend on the
+// deployment so that they resolve again the references to its outputs
+func (s *Server) evalDependents(dep *proto.Deployment) error {
+	_, dependents, err := s.State.GetDependencies(dep.Id)
+	if err != nil {
+		return err
+	}
+	for _, id := range dependents {
+		other, err := s.State.LoadDeployment(id)
+		if err != nil {
+			return err
+		}
+		if other.CompId == "" {
+			// not started yet, it resolves the outputs once it starts
+			continue
+		}
+		comp, err := s.State.ReadDeployment(id)
+		if err != nil {
+			return err
+		}
+		if comp == nil || comp.Action == proto.Component_DELETE {
+			continue
+		}
+		s.addEval(&proto.Evaluation{
+			Id:           uuid.UUID(),
+			Status:       proto.Evaluation_PENDING,
+			TriggeredBy:  proto.Evaluation_DEPENDENCY,
+			DeploymentID: id,
+			Type:         proto.EvaluationTypeCluster,
+		})
+	}
+	return nil
+}
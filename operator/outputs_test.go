@@ -0,0 +1,201 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/schema"
+)
+
+func TestResolveReferences(t *testing.T) {
+	spec := &proto.ClusterSpec{
+		Groups: []*proto.ClusterSpec_Group{
+			{
+				Params: schema.MapToSpec(map[string]interface{}{
+					"a": "${zk.main.connect}",
+					"b": map[string]interface{}{
+						"c": "host=${zk.main.host};port=${zk.other.port}",
+					},
+					"d": "plain",
+				}),
+			},
+		},
+	}
+
+	refs := specReferences(spec)
+	assert.Len(t, refs, 3)
+
+	outputs := map[string]string{
+		"${zk.main.connect}": "a:2181,b:2181",
+		"${zk.main.host}":    "a",
+		"${zk.other.port}":   "2182",
+	}
+	resolve := func(ref *Reference) (string, error) {
+		value, ok := outputs[ref.String()]
+		if !ok {
+			return "", fmt.Errorf("not found")
+		}
+		return value, nil
+	}
+
+	orig := gproto.Clone(spec).(*proto.ClusterSpec)
+	assert.NoError(t, resolveReferences(spec, resolve))
+
+	data := schema.NewResourceData(&schema.Schema2{
+		Spec: &schema.Record{
+			Fields: map[string]*schema.Field{
+				"a": {Type: schema.TypeString},
+				"b": {Type: &schema.Record{
+					Fields: map[string]*schema.Field{
+						"c": {Type: schema.TypeString},
+					},
+				}},
+				"d": {Type: schema.TypeString},
+			},
+		},
+	}, spec.Groups[0].Params)
+
+	assert.Equal(t, data.Get("a"), "a:2181,b:2181")
+	assert.Equal(t, data.Get("b.c"), "host=a;port=2182")
+	assert.Equal(t, data.Get("d"), "plain")
+
+	// the spec is not resolved if any of the references fails
+	delete(outputs, "${zk.other.port}")
+	assert.Error(t, resolveReferences(orig, resolve))
+	assert.Len(t, specReferences(orig), 3)
+}
+
+// outputsHandler publishes the running instances as an output
+type outputsHandler struct {
+	nullHandler
+}
+
+func (o *outputsHandler) GetOutputs() map[string]*Output {
+	return map[string]*Output{
+		"connect": {
+			Type: OutputEndpoint,
+			Value: func(dep *proto.Deployment) (string, error) {
+				return RunningEndpoints(dep, 2181), nil
+			},
+		},
+	}
+}
+
+func (o *outputsHandler) Outputs(dep *proto.Deployment) (map[string]string, error) {
+	return map[string]string{
+		"connect": RunningEndpoints(dep, 2181),
+	}, nil
+}
+
+func TestServer_Outputs(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+	s.handlers["zk"] = &outputsHandler{}
+
+	validate := func(params map[string]interface{}) (*proto.ClusterSpec, error) {
+		comp, err := s.validateComponent(&proto.Component{
+			Name: "b",
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				Groups: []*proto.ClusterSpec_Group{
+					{Count: 1, Params: schema.MapToSpec(params)},
+				},
+			}),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var spec proto.ClusterSpec
+		assert.NoError(t, gproto.Unmarshal(comp.Spec.Value, &spec))
+		return &spec, nil
+	}
+
+	// the referenced cluster is a dependency
+	spec, err := validate(map[string]interface{}{"zk": "${zk.a.connect}"})
+	assert.NoError(t, err)
+	assert.Equal(t, spec.DependsOn, []string{"a"})
+
+	// unknown output and backend
+	_, err = validate(map[string]interface{}{"zk": "${zk.a.unknown}"})
+	assert.Error(t, err)
+	_, err = validate(map[string]interface{}{"zk": "${other.a.connect}"})
+	assert.Error(t, err)
+
+	_, err = s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{Backend: "zk"}),
+	})
+	assert.NoError(t, err)
+	depA, err := s.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	_, err = s.State.Apply(&proto.Component{
+		Name: "b",
+		Spec: proto.MustMarshalAny(spec),
+	})
+	assert.NoError(t, err)
+	depB, err := s.State.NameToDeployment("b")
+	assert.NoError(t, err)
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{Id: depB, CompId: "b"}))
+
+	// the output is not published until the deployment is done
+	ref := &Reference{Backend: "zk", Cluster: "a", Output: "connect"}
+	_, err = s.ResolveOutput(ref)
+	assert.Error(t, err)
+
+	submit := func(names ...string) {
+		dep, err := s.State.LoadDeployment(depA)
+		assert.NoError(t, err)
+		dep.Backend = "zk"
+		dep.Instances = nil
+		for _, name := range names {
+			dep.Instances = append(dep.Instances, &proto.Instance{
+				ID:           name,
+				Name:         name,
+				ClusterName:  "a",
+				DeploymentID: depA,
+				Status:       proto.Instance_RUNNING,
+			})
+		}
+		assert.NoError(t, s.SubmitPlan(&proto.Evaluation{DeploymentID: depA}, &proto.Plan{
+			Deployment: dep,
+			Status:     proto.DeploymentDone,
+		}))
+	}
+
+	popEval := func() *proto.Evaluation {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		return s.evalQueue.pop(ctx)
+	}
+
+	submit("a-1")
+	value, err := s.ResolveOutput(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, value, "a-1.a:2181")
+
+	// the dependent cluster is evaluated again
+	eval := popEval()
+	if assert.NotNil(t, eval) {
+		assert.Equal(t, eval.DeploymentID, depB)
+		assert.Equal(t, eval.TriggeredBy, proto.Evaluation_DEPENDENCY)
+		s.evalQueue.finalize(eval.Id)
+	}
+
+	// the outputs did not change
+	submit("a-1")
+	assert.Nil(t, popEval())
+
+	submit("a-1", "a-2")
+	value, err = s.ResolveOutput(ref)
+	assert.NoError(t, err)
+	assert.Equal(t, value, "a-1.a:2181,a-2.a:2181")
+	assert.NotNil(t, popEval())
+
+	// the backend of the referenced cluster has to match
+	_, err = s.ResolveOutput(&Reference{Backend: "other", Cluster: "a", Output: "connect"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package operator
+
+import (
+	"fmt"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/teseraio/ensemble/lib/uuid"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// planResult is the result of verifying a plan against the current state
+type planResult struct {
+	// stale is set if the deployment changed after the plan was computed.
+	// None of the updates of the plan are applied in that case.
+	stale bool
+
+	// rejected are the instance updates that were not applied because
+	// the instance changed after the plan was computed
+	rejected []*proto.Instance
+}
+
+// refresh returns true if the plan was not fully applied and the
+// deployment has to be evaluated again with the current state
+func (r *planResult) refresh() bool {
+	return r.stale || len(r.rejected) != 0
+}
+
+// applyPlan verifies the plan against the current state of the deployment and
+// applies the instance updates that do not conflict with it. An update conflicts
+// if the modify index of the instance is not the same one the scheduler saw. A new
+// placement that replaces a rejected instance is rejected too. The caller must hold
+// the server lock.
+func (s *Server) applyPlan(eval *proto.Evaluation, p *proto.Plan) (*planResult, error) {
+	result := &planResult{}
+	if p.Deployment == nil && len(p.NodeUpdate) == 0 {
+		return result, nil
+	}
+
+	current, err := s.State.LoadDeployment(eval.DeploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("deployment does not exists '%s'", eval.DeploymentID)
+	}
+	if p.Deployment != nil && p.Deployment.ModifyIndex != current.ModifyIndex {
+		result.stale = true
+		return result, nil
+	}
+
+	instances := map[string]*proto.Instance{}
+	for _, i := range current.Instances {
+		instances[i.ID] = i
+	}
+
+	rejected := map[string]struct{}{}
+	accepted := []*proto.Instance{}
+	for _, i := range p.NodeUpdate {
+		conflict := false
+		if i.ModifyIndex == 0 {
+			// new placement
+			if _, ok := rejected[i.Prev]; ok && i.Prev != "" {
+				conflict = true
+			}
+		} else {
+			prev, ok := instances[i.ID]
+			if !ok || prev.ModifyIndex != i.ModifyIndex {
+				conflict = true
+			}
+		}
+		if conflict {
+			rejected[i.ID] = struct{}{}
+			result.rejected = append(result.rejected, i)
+		} else {
+			accepted = append(accepted, i)
+		}
+	}
+
+	for _, i := range accepted {
+		if err := s.upsertInstanceLocked(i); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// reevaluate creates a new evaluation for a deployment whose plan
+// could not be fully applied
+func (s *Server) reevaluate(eval *proto.Evaluation, result *planResult) {
+	s.logger.Debug("plan rejected", "eval", eval.Id, "cluster", eval.DeploymentID, "stale", result.stale, "rejected", len(result.rejected))
+
+	s.addEval(&proto.Evaluation{
+		Id:           uuid.UUID(),
+		Status:       proto.Evaluation_PENDING,
+		TriggeredBy:  proto.Evaluation_PLANREJECTED,
+		DeploymentID: eval.DeploymentID,
+		Type:         eval.Type,
+		Sequence:     eval.Sequence,
+		ComponentID:  eval.ComponentID,
+	})
+}
+
+// blockedChanged returns true if the reasons why the deployment
+// has not converged are not the same ones stored in the state
+func blockedChanged(prev, next []*proto.Deployment_BlockedReason) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+	for indx := range prev {
+		if !gproto.Equal(prev[indx], next[indx]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestServer_ApplyPlan(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:     "dep1",
+		Status: proto.DeploymentRunning,
+	}))
+	for _, id := range []string{"i0", "i1"} {
+		assert.NoError(t, s.UpsertInstance(&proto.Instance{
+			ID:           id,
+			DeploymentID: "dep1",
+			Status:       proto.Instance_RUNNING,
+		}))
+	}
+
+	popEval := func() *proto.Evaluation {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		eval := s.evalQueue.pop(ctx)
+		if eval != nil {
+			s.evalQueue.finalize(eval.Id)
+		}
+		return eval
+	}
+
+	// plan stops both instances and replaces i1
+	newPlan := func() *proto.Plan {
+		dep, err := s.State.LoadDeployment("dep1")
+		assert.NoError(t, err)
+
+		plan := &proto.Plan{
+			Deployment: dep,
+			Status:     proto.DeploymentDone,
+		}
+		for _, i := range dep.Instances {
+			i = i.Copy()
+			i.DesiredStatus = proto.Instance_STOP
+			plan.NodeUpdate = append(plan.NodeUpdate, i)
+		}
+		plan.NodeUpdate = append(plan.NodeUpdate, &proto.Instance{
+			ID:           "i2",
+			DeploymentID: "dep1",
+			Prev:         "i1",
+		})
+		return plan
+	}
+	eval := &proto.Evaluation{DeploymentID: "dep1", Type: proto.EvaluationTypeCluster}
+
+	// the deployment changes after the plan is computed
+	plan := newPlan()
+	assert.NoError(t, s.State.UpdateDeployment(plan.Deployment))
+	assert.NoError(t, s.SubmitPlan(eval, plan))
+
+	dep, err := s.State.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Len(t, dep.Instances, 2)
+	assert.Equal(t, dep.Status, proto.DeploymentRunning)
+	assert.True(t, plan.Stale)
+
+	if eval := popEval(); assert.NotNil(t, eval) {
+		assert.Equal(t, eval.TriggeredBy, proto.Evaluation_PLANREJECTED)
+	}
+
+	// i1 changes after the plan is computed
+	plan = newPlan()
+
+	i1, err := s.GetInstance("i1")
+	assert.NoError(t, err)
+	i1.Healthy = true
+	assert.NoError(t, s.UpsertInstance(i1))
+
+	assert.NoError(t, s.SubmitPlan(eval, plan))
+
+	// only the update of i0 is applied
+	i0, err := s.GetInstance("i0")
+	assert.NoError(t, err)
+	assert.Equal(t, i0.DesiredStatus, proto.Instance_STOP)
+
+	i1, err = s.GetInstance("i1")
+	assert.NoError(t, err)
+	assert.Equal(t, i1.DesiredStatus, proto.Instance_RUN)
+
+	_, err = s.GetInstance("i2")
+	assert.Error(t, err)
+	assert.Equal(t, plan.Rejected, []string{"i1", "i2"})
+
+	dep, err = s.State.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Equal(t, dep.Status, proto.DeploymentRunning)
+	assert.NotNil(t, popEval())
+
+	// the plan computed with the current state is applied
+	plan = newPlan()
+	assert.NoError(t, s.SubmitPlan(eval, plan))
+
+	dep, err = s.State.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Len(t, dep.Instances, 3)
+	assert.Equal(t, dep.Status, proto.DeploymentDone)
+	assert.Nil(t, popEval())
+}
@@ -0,0 +1,187 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// Probe configures how a check on an instance is run
+type Probe struct {
+	// Timeout is the maximum duration of each attempt. Zero means no timeout.
+	Timeout time.Duration
+
+	// Attempts is the number of times the check is run before it fails
+	Attempts int
+
+	// Interval is the time to wait between attempts
+	Interval time.Duration
+}
+
+// DefaultStartupProbe is used for the Startup function of the
+// backends that do not define their own probe
+var DefaultStartupProbe = &Probe{
+	Timeout:  2 * time.Minute,
+	Attempts: 3,
+	Interval: 5 * time.Second,
+}
+
+// run calls the check until it succeeds, the attempts are
+// exhausted or the context is cancelled
+func (p *Probe) run(ctx context.Context, check func(ctx context.Context) error) error {
+	attempts := p.Attempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt != 0 {
+			select {
+			case <-time.After(p.Interval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		attemptCtx, cancel := context.WithCancel(ctx)
+		if p.Timeout != 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, p.Timeout)
+		}
+		err = check(attemptCtx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+// instanceTask identifies a task running in the background for an instance
+type instanceTask struct {
+	kind string
+	id   string
+}
+
+type runningTask struct {
+	cancel context.CancelFunc
+}
+
+const (
+	taskStartup     = "startup"
+	taskHealthCheck = "health-check"
+)
+
+// startTask runs the task in the background for the instance unless there
+// is one of the same kind already running for it
+func (b *BaseOperator) startTask(ctx context.Context, kind string, id string, task func(ctx context.Context)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.tasks == nil {
+		b.tasks = map[instanceTask]*runningTask{}
+	}
+	key := instanceTask{kind: kind, id: id}
+	if _, ok := b.tasks[key]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	running := &runningTask{cancel: cancel}
+	b.tasks[key] = running
+
+	go func() {
+		defer cancel()
+		task(ctx)
+
+		b.lock.Lock()
+		if b.tasks[key] == running {
+			delete(b.tasks, key)
+		}
+		b.lock.Unlock()
+	}()
+}
+
+// cancelTasks stops the tasks running for the instance
+func (b *BaseOperator) cancelTasks(id string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for key, running := range b.tasks {
+		if key.id == id {
+			running.cancel()
+			delete(b.tasks, key)
+		}
+	}
+}
+
+// startProbe runs the startup probe of the instance in the background
+func (b *BaseOperator) startProbe(ctx context.Context, instance *proto.Instance) {
+	instance = instance.Copy()
+
+	b.startTask(ctx, taskStartup, instance.ID, func(ctx context.Context) {
+		err := b.runStartup(ctx, instance)
+		if ctx.Err() != nil {
+			// the probe was cancelled
+			return
+		}
+		if err := b.setHealth(instance.ID, err); err != nil {
+			b.logger.Error("failed to update the health of the instance", "id", instance.ID, "err", err)
+		}
+	})
+}
+
+func (b *BaseOperator) runStartup(ctx context.Context, instance *proto.Instance) error {
+	spec := b.handler.Spec()
+
+	probe := spec.StartupProbe
+	if probe == nil {
+		probe = DefaultStartupProbe
+	}
+	err := probe.run(ctx, func(ctx context.Context) error {
+		return spec.Startup(ctx, instance.Copy())
+	})
+	if err != nil {
+		return fmt.Errorf("startup probe failed: %v", err)
+	}
+	return nil
+}
+
+// setHealth updates the health of the instance with the result of the startup
+// probe or the health check. The instance is written only if it did not change
+// after it was read so that the update does not overwrite a plan applied in the
+// meantime. It is read again and the update retried otherwise.
+func (b *BaseOperator) setHealth(id string, checkErr error) error {
+	for {
+		instance, err := b.cplane.GetInstance(id)
+		if err != nil {
+			return err
+		}
+		if instance == nil || instance.Status != proto.Instance_RUNNING || instance.DesiredStatus == proto.Instance_STOP {
+			return nil
+		}
+
+		instance = instance.Copy()
+		if checkErr == nil {
+			instance.Healthy = true
+			instance.HealthError = ""
+			instance.UnhealthySince = nil
+		} else {
+			instance.Healthy = false
+			instance.HealthError = checkErr.Error()
+			if instance.UnhealthySince == nil {
+				instance.UnhealthySince = ptypes.TimestampNow()
+			}
+		}
+		if err := b.cplane.UpdateInstance(instance); err != ErrInstanceModified {
+			return err
+		}
+	}
+}
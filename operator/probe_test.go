@@ -0,0 +1,224 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestProbe_Run(t *testing.T) {
+	p := &Probe{
+		Timeout:  10 * time.Millisecond,
+		Attempts: 3,
+	}
+
+	// the check is retried until it succeeds
+	num := 0
+	err := p.run(context.Background(), func(ctx context.Context) error {
+		num++
+		if num != 2 {
+			return fmt.Errorf("not ready")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, num, 2)
+
+	// each attempt is cancelled after the timeout
+	num = 0
+	err = p.run(context.Background(), func(ctx context.Context) error {
+		num++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.Equal(t, err, context.DeadlineExceeded)
+	assert.Equal(t, num, 3)
+
+	// the probe stops if the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = p.run(ctx, func(ctx context.Context) error {
+		return fmt.Errorf("not ready")
+	})
+	assert.Equal(t, err, context.Canceled)
+}
+
+func waitForInstance(t *testing.T, c ControlPlane, id string, cond func(i *proto.Instance) bool) *proto.Instance {
+	for i := 0; i < 100; i++ {
+		instance, err := c.GetInstance(id)
+		assert.NoError(t, err)
+		if cond(instance) {
+			return instance
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("instance %s not updated", id)
+	return nil
+}
+
+func TestBaseOperator_StartupProbe(t *testing.T) {
+	cancelled := make(chan struct{})
+
+	b := newMockHandler(&Spec{
+		StartupProbe: &Probe{
+			Timeout:  time.Second,
+			Attempts: 2,
+		},
+		Startup: func(ctx context.Context, i *proto.Instance) error {
+			switch i.ID {
+			case "slow":
+				// blocks until the probe is cancelled
+				<-ctx.Done()
+				if ctx.Err() == context.Canceled {
+					close(cancelled)
+				}
+				return ctx.Err()
+			case "failed":
+				return fmt.Errorf("not ready")
+			}
+			return nil
+		},
+	})
+
+	c := &InmemControlPlane{}
+	b.Setup(c)
+
+	for _, id := range []string{"slow", "a", "failed"} {
+		assert.NoError(t, c.UpsertInstance(&proto.Instance{
+			ID:     id,
+			Status: proto.Instance_RUNNING,
+		}))
+	}
+
+	// the slow probe does not block the other instances
+	waitForInstance(t, c, "a", func(i *proto.Instance) bool {
+		return i.Healthy
+	})
+
+	// the instance is marked as failed after the attempts
+	i := waitForInstance(t, c, "failed", func(i *proto.Instance) bool {
+		return i.HealthError != ""
+	})
+	assert.False(t, i.Healthy)
+	assert.Equal(t, i.HealthError, "startup probe failed: not ready")
+
+	// the probe is cancelled if the instance stops
+	slow, err := c.GetInstance("slow")
+	assert.NoError(t, err)
+	slow.Status = proto.Instance_TAINTED
+	assert.NoError(t, c.UpsertInstance(slow))
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("probe not cancelled")
+	}
+}
+
+func TestBaseOperator_StartupProbeRestored(t *testing.T) {
+	c := &InmemControlPlane{}
+
+	// the instances were marked as unhealthy before the operator started
+	for _, id := range []string{"a", "b"} {
+		assert.NoError(t, c.UpsertInstance(&proto.Instance{
+			ID:          id,
+			Status:      proto.Instance_RUNNING,
+			HealthError: "startup probe failed: not ready",
+		}))
+	}
+
+	b := newMockHandler(&Spec{
+		StartupProbe: &Probe{
+			Timeout:  time.Second,
+			Attempts: 2,
+		},
+		Startup: func(ctx context.Context, i *proto.Instance) error {
+			if i.ID == "b" {
+				return fmt.Errorf("still not ready")
+			}
+			return nil
+		},
+	})
+	b.Setup(c)
+
+	// the instances are probed again
+	i := waitForInstance(t, c, "a", func(i *proto.Instance) bool {
+		return i.Healthy
+	})
+	assert.Empty(t, i.HealthError)
+
+	waitForInstance(t, c, "b", func(i *proto.Instance) bool {
+		return i.HealthError == "startup probe failed: still not ready"
+	})
+}
+
+func TestBaseOperator_StartupProbeRestoredOwnInstances(t *testing.T) {
+	// probed records the instances probed by each backend
+	var lock sync.Mutex
+	probed := map[string][]string{}
+
+	newHandler := func(name string, probeErr error) *mockHandler {
+		h := newMockHandler(&Spec{
+			StartupProbe: &Probe{
+				Timeout:  time.Second,
+				Attempts: 1,
+			},
+			Startup: func(ctx context.Context, i *proto.Instance) error {
+				lock.Lock()
+				probed[name] = append(probed[name], i.ID)
+				lock.Unlock()
+				return probeErr
+			},
+		})
+		h.name = name
+		return h
+	}
+
+	a := newHandler("a", fmt.Errorf("not ready"))
+	b := newHandler("b", nil)
+
+	s := testServer(t, a)
+	s.handlers = map[string]Handler{
+		"a": a,
+		"b": b,
+	}
+	defer s.events.close()
+
+	// the instances were marked as unhealthy before the operator started
+	for _, name := range []string{"a", "b"} {
+		assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+			Id:      "dep-" + name,
+			Backend: name,
+		}))
+		assert.NoError(t, s.UpsertInstance(&proto.Instance{
+			ID:           "i-" + name,
+			DeploymentID: "dep-" + name,
+			Status:       proto.Instance_RUNNING,
+			HealthError:  "startup probe failed: not ready",
+		}))
+	}
+
+	a.Setup(s.backendControlPlane("a"))
+	b.Setup(s.backendControlPlane("b"))
+
+	// each instance is only probed again by its own backend
+	waitForInstance(t, s, "i-b", func(i *proto.Instance) bool {
+		return i.Healthy
+	})
+	waitForInstance(t, s, "i-a", func(i *proto.Instance) bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(probed["a"]) != 0
+	})
+
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, probed["a"], []string{"i-a"})
+	assert.Equal(t, probed["b"], []string{"i-b"})
+}
@@ -22,6 +22,17 @@ type EnsembleServiceClient interface {
 	Apply(ctx context.Context, in *Component, opts ...grpc.CallOption) (*Component, error)
 	ListDeployments(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ListDeploymentsResp, error)
 	GetDeployment(ctx context.Context, in *GetDeploymentReq, opts ...grpc.CallOption) (*Deployment, error)
+	Restart(ctx context.Context, in *RestartReq, opts ...grpc.CallOption) (*Deployment, error)
+	ReplaceInstance(ctx context.Context, in *ReplaceInstanceReq, opts ...grpc.CallOption) (*Instance, error)
+	Pause(ctx context.Context, in *PauseReq, opts ...grpc.CallOption) (*Deployment, error)
+	Resume(ctx context.Context, in *ResumeReq, opts ...grpc.CallOption) (*Deployment, error)
+	Scale(ctx context.Context, in *ScaleReq, opts ...grpc.CallOption) (*Component, error)
+	Purge(ctx context.Context, in *PurgeReq, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetDependencyGraph(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DependencyGraph, error)
+	ListEvaluations(ctx context.Context, in *ListEvaluationsReq, opts ...grpc.CallOption) (*ListEvaluationsResp, error)
+	GetEvaluation(ctx context.Context, in *GetEvaluationReq, opts ...grpc.CallOption) (*Evaluation, error)
+	SnapshotSave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (EnsembleService_SnapshotSaveClient, error)
+	SnapshotRestore(ctx context.Context, opts ...grpc.CallOption) (EnsembleService_SnapshotRestoreClient, error)
 }
 
 type ensembleServiceClient struct {
@@ -59,6 +70,153 @@ func (c *ensembleServiceClient) GetDeployment(ctx context.Context, in *GetDeploy
 	return out, nil
 }
 
+func (c *ensembleServiceClient) Restart(ctx context.Context, in *RestartReq, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/Restart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) ReplaceInstance(ctx context.Context, in *ReplaceInstanceReq, opts ...grpc.CallOption) (*Instance, error) {
+	out := new(Instance)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/ReplaceInstance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) Pause(ctx context.Context, in *PauseReq, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) Resume(ctx context.Context, in *ResumeReq, opts ...grpc.CallOption) (*Deployment, error) {
+	out := new(Deployment)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) Scale(ctx context.Context, in *ScaleReq, opts ...grpc.CallOption) (*Component, error) {
+	out := new(Component)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/Scale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) Purge(ctx context.Context, in *PurgeReq, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/Purge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) GetDependencyGraph(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DependencyGraph, error) {
+	out := new(DependencyGraph)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/GetDependencyGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) ListEvaluations(ctx context.Context, in *ListEvaluationsReq, opts ...grpc.CallOption) (*ListEvaluationsResp, error) {
+	out := new(ListEvaluationsResp)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/ListEvaluations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) GetEvaluation(ctx context.Context, in *GetEvaluationReq, opts ...grpc.CallOption) (*Evaluation, error) {
+	out := new(Evaluation)
+	err := c.cc.Invoke(ctx, "/proto.EnsembleService/GetEvaluation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ensembleServiceClient) SnapshotSave(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (EnsembleService_SnapshotSaveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EnsembleService_ServiceDesc.Streams[0], "/proto.EnsembleService/SnapshotSave", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ensembleServiceSnapshotSaveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EnsembleService_SnapshotSaveClient interface {
+	Recv() (*SnapshotChunk, error)
+	grpc.ClientStream
+}
+
+type ensembleServiceSnapshotSaveClient struct {
+	grpc.ClientStream
+}
+
+func (x *ensembleServiceSnapshotSaveClient) Recv() (*SnapshotChunk, error) {
+	m := new(SnapshotChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ensembleServiceClient) SnapshotRestore(ctx context.Context, opts ...grpc.CallOption) (EnsembleService_SnapshotRestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EnsembleService_ServiceDesc.Streams[1], "/proto.EnsembleService/SnapshotRestore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ensembleServiceSnapshotRestoreClient{stream}
+	return x, nil
+}
+
+type EnsembleService_SnapshotRestoreClient interface {
+	Send(*SnapshotChunk) error
+	CloseAndRecv() (*empty.Empty, error)
+	grpc.ClientStream
+}
+
+type ensembleServiceSnapshotRestoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *ensembleServiceSnapshotRestoreClient) Send(m *SnapshotChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ensembleServiceSnapshotRestoreClient) CloseAndRecv() (*empty.Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(empty.Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EnsembleServiceServer is the server API for EnsembleService service.
 // All implementations must embed UnimplementedEnsembleServiceServer
 // for forward compatibility
@@ -66,6 +224,17 @@ type EnsembleServiceServer interface {
 	Apply(context.Context, *Component) (*Component, error)
 	ListDeployments(context.Context, *empty.Empty) (*ListDeploymentsResp, error)
 	GetDeployment(context.Context, *GetDeploymentReq) (*Deployment, error)
+	Restart(context.Context, *RestartReq) (*Deployment, error)
+	ReplaceInstance(context.Context, *ReplaceInstanceReq) (*Instance, error)
+	Pause(context.Context, *PauseReq) (*Deployment, error)
+	Resume(context.Context, *ResumeReq) (*Deployment, error)
+	Scale(context.Context, *ScaleReq) (*Component, error)
+	Purge(context.Context, *PurgeReq) (*empty.Empty, error)
+	GetDependencyGraph(context.Context, *empty.Empty) (*DependencyGraph, error)
+	ListEvaluations(context.Context, *ListEvaluationsReq) (*ListEvaluationsResp, error)
+	GetEvaluation(context.Context, *GetEvaluationReq) (*Evaluation, error)
+	SnapshotSave(*empty.Empty, EnsembleService_SnapshotSaveServer) error
+	SnapshotRestore(EnsembleService_SnapshotRestoreServer) error
 	mustEmbedUnimplementedEnsembleServiceServer()
 }
 
@@ -82,6 +251,39 @@ func (UnimplementedEnsembleServiceServer) ListDeployments(context.Context, *empt
 func (UnimplementedEnsembleServiceServer) GetDeployment(context.Context, *GetDeploymentReq) (*Deployment, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDeployment not implemented")
 }
+func (UnimplementedEnsembleServiceServer) Restart(context.Context, *RestartReq) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
+}
+func (UnimplementedEnsembleServiceServer) ReplaceInstance(context.Context, *ReplaceInstanceReq) (*Instance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplaceInstance not implemented")
+}
+func (UnimplementedEnsembleServiceServer) Pause(context.Context, *PauseReq) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedEnsembleServiceServer) Resume(context.Context, *ResumeReq) (*Deployment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedEnsembleServiceServer) Scale(context.Context, *ScaleReq) (*Component, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scale not implemented")
+}
+func (UnimplementedEnsembleServiceServer) Purge(context.Context, *PurgeReq) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Purge not implemented")
+}
+func (UnimplementedEnsembleServiceServer) GetDependencyGraph(context.Context, *empty.Empty) (*DependencyGraph, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDependencyGraph not implemented")
+}
+func (UnimplementedEnsembleServiceServer) ListEvaluations(context.Context, *ListEvaluationsReq) (*ListEvaluationsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEvaluations not implemented")
+}
+func (UnimplementedEnsembleServiceServer) GetEvaluation(context.Context, *GetEvaluationReq) (*Evaluation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEvaluation not implemented")
+}
+func (UnimplementedEnsembleServiceServer) SnapshotSave(*empty.Empty, EnsembleService_SnapshotSaveServer) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotSave not implemented")
+}
+func (UnimplementedEnsembleServiceServer) SnapshotRestore(EnsembleService_SnapshotRestoreServer) error {
+	return status.Errorf(codes.Unimplemented, "method SnapshotRestore not implemented")
+}
 func (UnimplementedEnsembleServiceServer) mustEmbedUnimplementedEnsembleServiceServer() {}
 
 // UnsafeEnsembleServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -149,6 +351,215 @@ func _EnsembleService_GetDeployment_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EnsembleService_Restart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestartReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).Restart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/Restart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).Restart(ctx, req.(*RestartReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_ReplaceInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceInstanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).ReplaceInstance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/ReplaceInstance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).ReplaceInstance(ctx, req.(*ReplaceInstanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).Pause(ctx, req.(*PauseReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).Resume(ctx, req.(*ResumeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_Scale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaleReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).Scale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/Scale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).Scale(ctx, req.(*ScaleReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_Purge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).Purge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/Purge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).Purge(ctx, req.(*PurgeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_GetDependencyGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).GetDependencyGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/GetDependencyGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).GetDependencyGraph(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_ListEvaluations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEvaluationsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).ListEvaluations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/ListEvaluations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).ListEvaluations(ctx, req.(*ListEvaluationsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_GetEvaluation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEvaluationReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnsembleServiceServer).GetEvaluation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.EnsembleService/GetEvaluation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnsembleServiceServer).GetEvaluation(ctx, req.(*GetEvaluationReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnsembleService_SnapshotSave_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(empty.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EnsembleServiceServer).SnapshotSave(m, &ensembleServiceSnapshotSaveServer{stream})
+}
+
+type EnsembleService_SnapshotSaveServer interface {
+	Send(*SnapshotChunk) error
+	grpc.ServerStream
+}
+
+type ensembleServiceSnapshotSaveServer struct {
+	grpc.ServerStream
+}
+
+func (x *ensembleServiceSnapshotSaveServer) Send(m *SnapshotChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EnsembleService_SnapshotRestore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EnsembleServiceServer).SnapshotRestore(&ensembleServiceSnapshotRestoreServer{stream})
+}
+
+type EnsembleService_SnapshotRestoreServer interface {
+	SendAndClose(*empty.Empty) error
+	Recv() (*SnapshotChunk, error)
+	grpc.ServerStream
+}
+
+type ensembleServiceSnapshotRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *ensembleServiceSnapshotRestoreServer) SendAndClose(m *empty.Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ensembleServiceSnapshotRestoreServer) Recv() (*SnapshotChunk, error) {
+	m := new(SnapshotChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EnsembleService_ServiceDesc is the grpc.ServiceDesc for EnsembleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -168,7 +579,54 @@ var EnsembleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDeployment",
 			Handler:    _EnsembleService_GetDeployment_Handler,
 		},
+		{
+			MethodName: "Restart",
+			Handler:    _EnsembleService_Restart_Handler,
+		},
+		{
+			MethodName: "ReplaceInstance",
+			Handler:    _EnsembleService_ReplaceInstance_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _EnsembleService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _EnsembleService_Resume_Handler,
+		},
+		{
+			MethodName: "Scale",
+			Handler:    _EnsembleService_Scale_Handler,
+		},
+		{
+			MethodName: "Purge",
+			Handler:    _EnsembleService_Purge_Handler,
+		},
+		{
+			MethodName: "GetDependencyGraph",
+			Handler:    _EnsembleService_GetDependencyGraph_Handler,
+		},
+		{
+			MethodName: "ListEvaluations",
+			Handler:    _EnsembleService_ListEvaluations_Handler,
+		},
+		{
+			MethodName: "GetEvaluation",
+			Handler:    _EnsembleService_GetEvaluation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SnapshotSave",
+			Handler:       _EnsembleService_SnapshotSave_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SnapshotRestore",
+			Handler:       _EnsembleService_SnapshotRestore_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "operator/proto/structs.proto",
 }
@@ -38,6 +38,20 @@ const (
 	DeploymentFailed    = "failed"
 )
 
+const (
+	// ConditionProgressDeadline is set when the deployment does not
+	// finish before the progress deadline
+	ConditionProgressDeadline = "ProgressDeadlineExceeded"
+
+	// ConditionInstancesLost is set when some instances cannot be
+	// rescheduled anymore
+	ConditionInstancesLost = "InstancesLost"
+
+	// ConditionInstancesUnhealthy is set when some instances fail
+	// the startup probe or the health check of the backend
+	ConditionInstancesUnhealthy = "InstancesUnhealthy"
+)
+
 const (
 	InstanceDesiredRunning = "running"
 	InstanceDesiredStopped = "stopped"
@@ -71,6 +85,44 @@ func (d *Deployment) Copy() *Deployment {
 	return proto.Clone(d).(*Deployment)
 }
 
+func (p *Plan) Copy() *Plan {
+	return proto.Clone(p).(*Plan)
+}
+
+func (e *Evaluation) Copy() *Evaluation {
+	return proto.Clone(e).(*Evaluation)
+}
+
+func (m *Instance_Mount) Copy() *Instance_Mount {
+	return proto.Clone(m).(*Instance_Mount)
+}
+
+// VolumeName returns the name of the volume for a mount of the instance. The volume
+// is bound to the name of the instance so that it is kept if the instance is replaced.
+func (n *Instance) VolumeName(m *Instance_Mount) string {
+	name := n.Name + "-" + m.Name
+	if m.Id != "" {
+		name += "-" + m.Id
+	}
+	return name
+}
+
+// WipedMounts returns the mounts of the previous instance whose volumes are not
+// used by the instance since they were replaced with new ones (see Wipe)
+func (n *Instance) WipedMounts(prev *Instance) []*Instance_Mount {
+	volumes := map[string]string{}
+	for _, m := range n.Mounts {
+		volumes[m.Name] = n.VolumeName(m)
+	}
+	res := []*Instance_Mount{}
+	for _, m := range prev.Mounts {
+		if volume, ok := volumes[m.Name]; ok && volume != prev.VolumeName(m) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
 func (n *Instance) FullName() string {
 	if n.ClusterName != "" {
 		//if n.DnsSuffix != "" {
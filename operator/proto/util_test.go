@@ -69,3 +69,35 @@ func TestParseIndex(t *testing.T) {
 		}
 	}
 }
+
+func TestWipedMounts(t *testing.T) {
+	prev := &Instance{
+		Name: "a-1",
+		Mounts: []*Instance_Mount{
+			{Name: "data", Id: "1"},
+			{Name: "logs"},
+		},
+	}
+
+	// the volumes are reused
+	i := &Instance{
+		Name:   "a-1",
+		Mounts: prev.Mounts,
+	}
+	if wiped := i.WipedMounts(prev); len(wiped) != 0 {
+		t.Fatal("bad")
+	}
+
+	// the volumes are wiped
+	i = &Instance{
+		Name: "a-1",
+		Mounts: []*Instance_Mount{
+			{Name: "data", Id: "2"},
+			{Name: "logs", Id: "3"},
+		},
+	}
+	wiped := i.WipedMounts(prev)
+	if !reflect.DeepEqual(wiped, prev.Mounts) {
+		t.Fatal("bad")
+	}
+}
@@ -3,6 +3,12 @@ package operator
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
@@ -33,6 +39,9 @@ type reconciler struct {
 	spec     *proto.ClusterSpec
 	res      *reconcileResult
 	updateFn updateFn
+
+	// now is the time of the evaluation (the current time if not set)
+	now time.Time
 }
 
 type allocSet []*proto.Instance
@@ -153,6 +162,15 @@ func (a *allocSet) canaries() (canaries allocSet, add allocSet, healthy allocSet
 	return
 }
 
+// byOrdinalDesc returns a copy of the set sorted by ordinal from the highest
+func (a *allocSet) byOrdinalDesc() (res allocSet) {
+	res = append(allocSet{}, *a...)
+	sort.SliceStable(res, func(i, j int) bool {
+		return instanceOrdinal(res[i]) > instanceOrdinal(res[j])
+	})
+	return
+}
+
 func (a *allocSet) add(other allocSet) {
 	existing := map[string]struct{}{}
 	for _, item := range *a {
@@ -195,10 +213,39 @@ type reconcileResult struct {
 	stop         []instanceStopResult
 	ready        []*proto.Instance
 	out          []*proto.Instance
+	lost         []*proto.Instance
+	blocked      []*proto.Deployment_BlockedReason
 	done         bool
 	completed    bool
 }
 
+// block records why the group (or the whole cluster if the group is nil) has not converged yet
+func (r *reconciler) block(grp *proto.ClusterSpec_Group, format string, args ...interface{}) {
+	reason := &proto.Deployment_BlockedReason{
+		Message: fmt.Sprintf(format, args...),
+	}
+	if grp != nil {
+		reason.Group = grp.Type
+	}
+	r.res.blocked = append(r.res.blocked, reason)
+}
+
+// describeInstances returns a readable list with the names of the instances
+func describeInstances(set allocSet) string {
+	names := []string{}
+	for _, i := range set {
+		if i.Name != "" {
+			names = append(names, i.Name)
+		} else {
+			names = append(names, i.ID)
+		}
+	}
+	if len(names) == 1 {
+		return "instance " + names[0]
+	}
+	return "instances " + strings.Join(names, ", ")
+}
+
 func (r *reconcileResult) print() {
 	fmt.Printf("place: %d, stop: %d, ready: %d\n", len(r.place), len(r.stop), len(r.ready))
 }
@@ -211,6 +258,7 @@ type instanceStopResult struct {
 
 type instancePlaceResult struct {
 	name       string
+	ordinal    int64
 	instance   *proto.Instance
 	group      *proto.ClusterSpec_Group
 	update     bool
@@ -222,6 +270,9 @@ type groupUpdate struct {
 	status string
 }
 
+// computeStop returns the instances to stop to scale down the group. The failed
+// instances are stopped first, then the ones with the highest ordinals so that
+// the group keeps the lowest ordinals without gaps.
 func (r *reconciler) computeStop(grp *proto.ClusterSpec_Group, reschedule allocSet, untainted allocSet) (stop allocSet) {
 	stop = allocSet{}
 	remove := len(untainted) + len(reschedule) - int(grp.Count)
@@ -229,17 +280,16 @@ func (r *reconciler) computeStop(grp *proto.ClusterSpec_Group, reschedule allocS
 	if remove <= 0 {
 		return
 	}
-	for i := 0; i < len(reschedule); i++ {
-		stop = append(stop, reschedule[i])
+	for _, i := range reschedule.byOrdinalDesc() {
+		stop = append(stop, i)
 		remove--
 		if remove == 0 {
 			return
 		}
 	}
 
-	for i := 0; i < len(untainted); i++ {
-		// TODO: Sort by health check
-		stop = append(stop, untainted[i])
+	for _, i := range untainted.byOrdinalDesc() {
+		stop = append(stop, i)
 		remove--
 		if remove == 0 {
 			return
@@ -248,13 +298,14 @@ func (r *reconciler) computeStop(grp *proto.ClusterSpec_Group, reschedule allocS
 	return stop
 }
 
-func (r *reconciler) computePlacements(grp *proto.ClusterSpec_Group, untainted, destructive allocSet, placedCanaries int) (place []instancePlaceResult) {
+func (r *reconciler) computePlacements(grp *proto.ClusterSpec_Group, set, untainted, destructive allocSet, placedCanaries int) (place []instancePlaceResult) {
 	place = []instancePlaceResult{}
 	total := len(untainted) + len(destructive) + placedCanaries
 
+	ordinals := freeOrdinals(set)
 	for i := total; i < int(grp.Count); i++ {
 		id := uuid.UUID8()
-		indx := i + 1 // index starts with 1
+		indx := ordinals()
 
 		// name of the node
 		var name string
@@ -264,15 +315,96 @@ func (r *reconciler) computePlacements(grp *proto.ClusterSpec_Group, untainted,
 			name = fmt.Sprintf("%s-%s-%d", id, grp.Type, indx)
 		}
 		place = append(place, instancePlaceResult{
-			name:  name,
-			group: grp,
+			name:    name,
+			ordinal: indx,
+			group:   grp,
 		})
 	}
 	return
 }
 
+// instanceOrdinal returns the ordinal of the instance in the group. Instances
+// created before the ordinal was tracked use the index in the name.
+func instanceOrdinal(i *proto.Instance) int64 {
+	if i.Ordinal != 0 {
+		return i.Ordinal
+	}
+	indx, err := proto.ParseIndex(i.Name)
+	if err != nil {
+		return 0
+	}
+	return int64(indx)
+}
+
+// freeOrdinals returns an iterator over the ordinals (starting with 1) that
+// are not held by any instance in the set, lowest first.
+func freeOrdinals(set allocSet) func() int64 {
+	used := map[int64]struct{}{}
+	for _, i := range set {
+		used[instanceOrdinal(i)] = struct{}{}
+	}
+	next := int64(0)
+	return func() int64 {
+		for {
+			next++
+			if _, ok := used[next]; !ok {
+				return next
+			}
+		}
+	}
+}
+
 var maxParallel = 2
 
+// disruptionBudget returns the number of healthy instances in the group that can
+// be voluntarily stopped without violating the minAvailable/maxUnavailable settings.
+// The instances that the operator is already stopping (i.e. scale down) count as
+// unavailable. It returns -1 if the group does not define any budget.
+func disruptionBudget(grp *proto.ClusterSpec_Group, set allocSet) int {
+	if grp.MinAvailable == 0 && grp.MaxUnavailable == 0 {
+		return -1
+	}
+
+	available, unavailable := 0, 0
+	for _, i := range set {
+		if i.IsHealthy() && i.DesiredStatus == proto.Instance_RUN {
+			available++
+		} else if i.Status != proto.Instance_STOPPED || i.DesiredStatus == proto.Instance_RUN {
+			// the instance is unhealthy, failed or still being stopped
+			unavailable++
+		}
+	}
+
+	var budget int
+	if grp.MinAvailable != 0 {
+		budget = available - int(grp.MinAvailable)
+	} else {
+		budget = int(grp.MaxUnavailable) - unavailable
+	}
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// limitDisruptions returns the subset of the instances to stop that fits in the
+// disruption budget. Instances that are not healthy do not consume budget.
+func limitDisruptions(budget int, stop allocSet) (res allocSet) {
+	res = allocSet{}
+	for _, i := range stop {
+		if i.IsHealthy() && i.DesiredStatus == proto.Instance_RUN {
+			if budget == 0 {
+				continue
+			}
+			if budget > 0 {
+				budget--
+			}
+		}
+		res = append(res, i)
+	}
+	return
+}
+
 func min(i, j int) int {
 	if i < j {
 		return i
@@ -284,6 +416,9 @@ func (r *reconciler) Compute() {
 	if r.updateFn == nil {
 		r.updateFn = diffUpdateFn
 	}
+	if r.now.IsZero() {
+		r.now = time.Now()
+	}
 	r.res = &reconcileResult{}
 
 	/*
@@ -309,20 +444,110 @@ func (r *reconciler) Compute() {
 			// delete completed
 			r.res.done = true
 			r.res.completed = true
+		} else {
+			r.block(nil, "deleting, waiting for the instances to stop")
 		}
 		return
 	}
 
+	// compute the groups in dependency order. A group is only computed once
+	// all the groups it depends on are done. Independent groups are computed
+	// at the same time.
 	done := true
-	for _, grp := range r.spec.Groups {
-		done = r.computeGroup(grp)
-		if !done {
+	groupDone := map[string]bool{}
+
+	pending := r.spec.Groups
+	for len(pending) != 0 {
+		next := []*proto.ClusterSpec_Group{}
+		for _, grp := range pending {
+			ready, blocked := true, []string{}
+			for _, dep := range grp.DependsOn {
+				depDone, ok := groupDone[dep]
+				if !ok {
+					ready = false
+				} else if !depDone {
+					blocked = append(blocked, dep)
+				}
+			}
+			if !ready {
+				next = append(next, grp)
+				continue
+			}
+			if len(blocked) != 0 {
+				r.block(grp, "blocked by group ordering, waiting for %s", strings.Join(blocked, ", "))
+				groupDone[grp.Type] = false
+			} else {
+				groupDone[grp.Type] = r.computeGroup(grp)
+			}
+			if !groupDone[grp.Type] {
+				done = false
+			}
+		}
+		if len(next) == len(pending) {
+			// there is a cycle or an unknown dependency
+			for _, grp := range next {
+				r.block(grp, "blocked by group ordering, unresolved dependencies")
+			}
+			done = false
 			break
 		}
+		pending = next
 	}
 	r.res.done = done
 }
 
+// validateGroupDependencies checks that the dependencies between
+// the groups reference existing groups and do not have cycles
+func validateGroupDependencies(spec *proto.ClusterSpec) error {
+	groups := map[string]*proto.ClusterSpec_Group{}
+	for _, grp := range spec.Groups {
+		if _, ok := groups[grp.Type]; ok {
+			return fmt.Errorf("group '%s' is duplicated", grp.Type)
+		}
+		groups[grp.Type] = grp
+	}
+	for _, grp := range spec.Groups {
+		for _, dep := range grp.DependsOn {
+			if dep == grp.Type {
+				return fmt.Errorf("group '%s' cannot depend on itself", grp.Type)
+			}
+			if _, ok := groups[dep]; !ok {
+				return fmt.Errorf("group '%s' depends on unknown group '%s'", grp.Type, dep)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+
+	var visit func(typ string) error
+	visit = func(typ string) error {
+		switch state[typ] {
+		case visiting:
+			return fmt.Errorf("cycle in the dependencies of group '%s'", typ)
+		case visited:
+			return nil
+		}
+		state[typ] = visiting
+		for _, dep := range groups[typ].DependsOn {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		state[typ] = visited
+		return nil
+	}
+	for _, grp := range spec.Groups {
+		if err := visit(grp.Type); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 	set := allocSet(r.dep.Instances)
 	set = set.byGroup(grp.Type)
@@ -333,12 +558,20 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 	// detect the stopped nodes
 	reschedule, lost, untainted := set.reschedule()
 
+	r.res.lost = append(r.res.lost, lost...)
+	if len(lost) != 0 {
+		r.block(grp, "reschedule attempts exhausted for %s", describeInstances(lost))
+	}
+
 	var stopping allocSet
 	stopping, untainted = untainted.filterByStopping()
 
 	var stopped allocSet
 	stopped, untainted = untainted.filterByStatus(proto.Instance_STOPPED)
 
+	// number of healthy instances that can be voluntarily stopped
+	budget := disruptionBudget(grp, set)
+
 	var stop allocSet
 	if grp.Count == 0 {
 		// purge the group
@@ -347,10 +580,26 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 		// scale down
 		stop = r.computeStop(grp, reschedule, untainted)
 	}
+	scaleDown := len(stop) != 0
+
+	stop = limitDisruptions(budget, stop)
+
+	if scaleDown {
+		if len(stop) == 0 {
+			r.block(grp, "scale down blocked by the disruption budget")
+		} else {
+			r.block(grp, "scaling down, stopping %s", describeInstances(stop))
+		}
+	}
 
 	// remove the reschedule nodes if we are stopping any
 	reschedule = reschedule.difference(stop)
+	if len(reschedule) != 0 {
+		r.block(grp, "rescheduling %s", describeInstances(reschedule))
+	}
 	for _, i := range reschedule {
+		// the replacement takes over the identity of the stopped instance
+		r.res.out = append(r.res.out, i)
 		r.res.place = append(r.res.place, instancePlaceResult{
 			instance:   i,
 			reschedule: true,
@@ -364,7 +613,7 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 			instance: i,
 		})
 	}
-	if len(stop) != 0 {
+	if scaleDown {
 		return false
 	}
 
@@ -404,17 +653,36 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 
 	untainted = untainted.join(promoted)
 
+	// replace the instances that are unhealthy for too long
+	var expired allocSet
+	expired, untainted = untainted.filter(func(i *proto.Instance) bool {
+		return unhealthyExpired(grp, i, r.now)
+	})
+	if len(expired) != 0 {
+		r.block(grp, "replacing unhealthy %s", describeInstances(expired))
+	}
+
 	// destructive updates (TODO: inplace updates)
 	var destructive allocSet
-	destructive, untainted = computeUpdates(r.spec, grp, untainted, r.updateFn)
+	destructive, untainted = computeUpdates(r.spec, grp, untainted, r.updateFn, r.dep.Restarts[grp.Type])
+	destructive = destructive.join(expired)
 
 	// rolling update
 	updates := []instanceStopResult{}
 
+	// the untainted set is computed again from the whole group with the canaries,
+	// only the instances that are not stopped nor being stopped are rolled
+	rollable, _ := destructive.filter(func(i *proto.Instance) bool {
+		return i.DesiredStatus == proto.Instance_RUN && i.Status != proto.Instance_STOPPED && i.Status != proto.Instance_TAINTED
+	})
+
 	areCanaries := len(canaries) + len(readyToAllocate)
-	if len(destructive) > 0 && areCanaries == 0 {
-		num := min(len(destructive), maxParallel)
-		for _, instance := range destructive[:num] {
+	if areCanaries != 0 {
+		r.block(grp, "canaries pending (%d)", areCanaries)
+	}
+	if len(rollable) > 0 && areCanaries == 0 {
+		num := min(len(rollable), maxParallel)
+		for _, instance := range limitDisruptions(budget, rollable[:num]) {
 			updates = append(updates, instanceStopResult{
 				instance: instance,
 				group:    grp,
@@ -424,20 +692,52 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 	}
 	isRolling := len(updates) != 0
 
+	if num := len(destructive) - len(expired); num > 0 {
+		updated := int(grp.Count) - num
+		if updated < 0 {
+			updated = 0
+		}
+		r.block(grp, "rolling update %d/%d", updated, grp.Count)
+	}
+	if len(rollable) > 0 && areCanaries == 0 && !isRolling {
+		r.block(grp, "rolling update blocked by the disruption budget")
+	}
+	if len(stopping) != 0 {
+		r.block(grp, "waiting for %s to stop", describeInstances(stopping))
+	}
+
 	// we can make the canaries part of the untainted
 	untainted = untainted.join(canaries)
 
 	// check if all the instances are healthy
-	allHealthy := true
-	for _, i := range untainted {
-		if i.Status == proto.Instance_PENDING || !i.Healthy {
-			allHealthy = false
-			break
-		}
-	}
+	unhealthy, _ := untainted.filter(func(i *proto.Instance) bool {
+		return i.Status == proto.Instance_PENDING || !i.Healthy
+	})
+	allHealthy := len(unhealthy) == 0
 
 	// compute placements
-	place := r.computePlacements(grp, untainted, destructive, len(readyToAllocate)) // sketchy right now
+	place := r.computePlacements(grp, set, untainted, destructive, len(readyToAllocate)) // sketchy right now
+
+	if !allHealthy {
+		failed, waiting := unhealthy.filter(func(i *proto.Instance) bool {
+			return i.HealthError != ""
+		})
+		if len(failed) != 0 {
+			r.block(grp, "unhealthy %s", describeInstances(failed))
+		}
+		if len(waiting) != 0 {
+			r.block(grp, "waiting for %s to become healthy", describeInstances(waiting))
+		}
+		if len(place) != 0 {
+			r.block(grp, "scale up of %d instances deferred until all the instances are healthy", len(place))
+		}
+	} else if len(place) != 0 {
+		if len(updates) != 0 {
+			r.block(grp, "scale up of %d instances deferred until the rolling update finishes", len(place))
+		} else {
+			r.block(grp, "placing %d instances", len(place))
+		}
+	}
 
 	if allHealthy {
 		// only place new allocs for scale up if the cluster is stable
@@ -455,20 +755,38 @@ func (r *reconciler) computeGroup(grp *proto.ClusterSpec_Group) bool {
 
 	done := false
 	if allHealthy {
-		if len(reschedule) == 0 && len(readyToAllocate) == 0 && len(stopping) == 0 && len(updates) == 0 && len(place) == 0 && len(lost) == 0 && !isRolling {
+		if len(reschedule) == 0 && len(readyToAllocate) == 0 && len(stopping) == 0 && len(destructive) == 0 && len(place) == 0 && len(lost) == 0 && !isRolling {
 			done = true
 		}
 	}
 	return done
 }
 
-func computeUpdates(spec *proto.ClusterSpec, grp *proto.ClusterSpec_Group, alloc allocSet, updateFn updateFn) (destructive allocSet, untainted allocSet) {
+// unhealthyExpired returns true if the instance has been unhealthy
+// for longer than the group allows
+func unhealthyExpired(grp *proto.ClusterSpec_Group, i *proto.Instance, now time.Time) bool {
+	if grp.ReplaceUnhealthySeconds == 0 || i.UnhealthySince == nil || i.Status != proto.Instance_RUNNING {
+		return false
+	}
+	since, err := ptypes.Timestamp(i.UnhealthySince)
+	if err != nil {
+		return false
+	}
+	return !now.Before(since.Add(time.Duration(grp.ReplaceUnhealthySeconds) * time.Second))
+}
+
+func computeUpdates(spec *proto.ClusterSpec, grp *proto.ClusterSpec_Group, alloc allocSet, updateFn updateFn, restart int64) (destructive allocSet, untainted allocSet) {
 	untainted = allocSet{}
 	destructive = allocSet{}
 
 	for _, i := range alloc {
-		if spec.Sequence != i.Sequence {
-			// check if the changes are destructive
+		if i.Replace || i.Restart < restart {
+			// the instance is marked to be replaced or it was created
+			// before the last restart of the group
+			destructive = append(destructive, i)
+		} else if spec.Sequence != i.Sequence || !gproto.Equal(grp.Params, i.Group.Params) {
+			// check if the changes are destructive. The params might change
+			// without a new sequence if a referenced output changes.
 			if updateFn(grp, i.Group) {
 				destructive = append(destructive, i)
 			} else {
@@ -1,8 +1,11 @@
 package operator
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
@@ -79,6 +82,14 @@ func testExpectReconcile(t *testing.T, reconciler *reconciler, expect expectedRe
 	}
 }
 
+func blockedMessages(rec *reconciler) []string {
+	msgs := []string{}
+	for _, b := range rec.res.blocked {
+		msgs = append(msgs, b.Message)
+	}
+	return msgs
+}
+
 func TestReconciler_Place_Empty(t *testing.T) {
 	spec := mockClusterSpec()
 	spec.Groups[0].Count = 5
@@ -188,6 +199,12 @@ func TestReconciler_ScaleUp_Blocked(t *testing.T) {
 	testExpectReconcile(t, rec, expectedReconciler{
 		place: 0,
 	})
+
+	msgs := blockedMessages(rec)
+	if assert.Len(t, msgs, 2) {
+		assert.Contains(t, msgs[0], "to become healthy")
+		assert.Equal(t, msgs[1], "scale up of 5 instances deferred until all the instances are healthy")
+	}
 }
 
 func TestReconciler_ScaleUp(t *testing.T) {
@@ -215,6 +232,66 @@ func TestReconciler_ScaleUp(t *testing.T) {
 	})
 }
 
+func TestReconciler_ScaleUp_ReuseOrdinals(t *testing.T) {
+	// the placed instances take the lowest ordinals released by
+	// the instances removed in a previous scale down
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 4
+
+	dep := newMockDeployment()
+	for _, ordinal := range []int64{2, 4} {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		ii.Ordinal = ordinal
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		place: 2,
+	})
+
+	assert.Equal(t, rec.res.place[0].ordinal, int64(1))
+	assert.Equal(t, rec.res.place[1].ordinal, int64(3))
+}
+
+func TestReconciler_ScaleUp_OrdinalFromName(t *testing.T) {
+	// instances without an ordinal use the index in the name
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+
+	dep := newMockDeployment()
+	for _, name := range []string{"a-1", "b-3"} {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Name = name
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		place: 1,
+	})
+
+	assert.Equal(t, rec.res.place[0].ordinal, int64(2))
+}
+
 func TestReconciler_ScaleDown(t *testing.T) {
 	// 10 -> 5
 	spec := mockClusterSpec()
@@ -268,6 +345,39 @@ func TestReconciler_ScaleDown(t *testing.T) {
 	})
 }
 
+func TestReconciler_ScaleDown_HighestOrdinals(t *testing.T) {
+	// 5 -> 2, the instances with the highest ordinals are stopped
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+
+	dep := newMockDeployment()
+	for _, ordinal := range []int64{3, 1, 5, 2, 4} {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		ii.Ordinal = ordinal
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 3,
+	})
+
+	ordinals := []int64{}
+	for _, i := range rec.res.stop {
+		ordinals = append(ordinals, i.instance.Ordinal)
+	}
+	assert.Equal(t, ordinals, []int64{5, 4, 3})
+}
+
 func TestReconciler_ScaleDown_Complete(t *testing.T) {
 	// 10 -> 5
 	spec := mockClusterSpec()
@@ -301,6 +411,90 @@ func TestReconciler_ScaleDown_Complete(t *testing.T) {
 	})
 }
 
+func TestReconciler_ScaleDown_DisruptionBudget(t *testing.T) {
+	// 6 -> 4 with two unhealthy instances, the healthy ones
+	// cannot be stopped without breaking the budget
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 4
+	spec.Groups[0].MinAvailable = 4
+
+	dep := newMockDeployment()
+	for i := 0; i < 6; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = i < 4
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 0,
+		done: false,
+	})
+
+	// once the budget allows it, the healthy instances are stopped
+	spec.Groups[0].MinAvailable = 3
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+		done: false,
+	})
+}
+
+func TestReconciler_ScaleDown_MaxUnavailable(t *testing.T) {
+	// 6 -> 3 stopping one instance at a time
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+	spec.Groups[0].MaxUnavailable = 1
+
+	dep := newMockDeployment()
+	for i := 0; i < 6; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+	})
+	stopped := rec.res.stop[0].instance
+
+	// the instance being stopped consumes the budget
+	stopped.DesiredStatus = proto.Instance_STOP
+	stopped.Status = proto.Instance_TAINTED
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 0,
+	})
+	assert.Equal(t, blockedMessages(rec), []string{"scale down blocked by the disruption budget"})
+
+	// and it is released once the instance is stopped
+	stopped.Status = proto.Instance_STOPPED
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+	})
+}
+
 func TestReconciler_ScaleDown_Zero(t *testing.T) {
 	// group to zero
 	spec := mockClusterSpec()
@@ -361,6 +555,121 @@ func TestReconciler_MultipleGroup_Unblock(t *testing.T) {
 	})
 }
 
+func TestReconciler_MultipleGroup_Parallel(t *testing.T) {
+	// groups without dependencies are deployed at the same time
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+	spec.Groups[0].Type = "typ1"
+
+	spec.Groups = append(spec.Groups, &proto.ClusterSpec_Group{
+		Count: 2,
+		Type:  "typ2",
+	})
+
+	rec := &reconciler{
+		dep:  newMockDeployment().Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		place: 5,
+	})
+}
+
+func TestReconciler_MultipleGroup_DependsOn(t *testing.T) {
+	// the dependent group is declared first but it is
+	// not deployed until the other group is done
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+	spec.Groups[0].Type = "worker"
+	spec.Groups[0].DependsOn = []string{"scheduler"}
+
+	spec.Groups = append(spec.Groups, &proto.ClusterSpec_Group{
+		Count: 1,
+		Type:  "scheduler",
+	})
+
+	dep := newMockDeployment()
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		place: 1,
+	})
+	assert.Equal(t, rec.res.place[0].group.Type, "scheduler")
+
+	// the scheduler is running but not healthy yet
+	dep.Instances = append(dep.Instances, &proto.Instance{
+		ID:     uuid.UUID(),
+		Group:  spec.Groups[1],
+		Status: proto.Instance_RUNNING,
+	})
+
+	rec.Compute()
+	testExpectReconcile(t, rec, expectedReconciler{})
+
+	assert.Contains(t, rec.res.blocked, &proto.Deployment_BlockedReason{
+		Group:   "worker",
+		Message: "blocked by group ordering, waiting for scheduler",
+	})
+
+	// the scheduler is healthy
+	dep.Instances[0].Healthy = true
+
+	rec.Compute()
+	testExpectReconcile(t, rec, expectedReconciler{
+		place: 2,
+	})
+	assert.Equal(t, rec.res.place[0].group.Type, "worker")
+}
+
+func TestReconciler_ValidateGroupDependencies(t *testing.T) {
+	cases := []struct {
+		deps map[string][]string
+		err  bool
+	}{
+		{
+			deps: map[string][]string{"a": {}, "b": {"a"}, "c": {"a", "b"}},
+		},
+		{
+			// unknown group
+			deps: map[string][]string{"a": {"d"}},
+			err:  true,
+		},
+		{
+			// self reference
+			deps: map[string][]string{"a": {"a"}},
+			err:  true,
+		},
+		{
+			// cycle
+			deps: map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}},
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		spec := &proto.ClusterSpec{}
+		for typ, deps := range c.deps {
+			spec.Groups = append(spec.Groups, &proto.ClusterSpec_Group{
+				Type:      typ,
+				DependsOn: deps,
+			})
+		}
+		err := validateGroupDependencies(spec)
+		if c.err {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
 func TestReconciler_Purge(t *testing.T) {
 	// First eval: Remove the whole cluster
 	spec := mockClusterSpec()
@@ -449,6 +758,7 @@ func TestReconciler_RollingUpgradeX(t *testing.T) {
 	testExpectReconcile(t, rec, expectedReconciler{
 		stop: 2,
 	})
+	assert.Equal(t, blockedMessages(rec), []string{"rolling update 0/5"})
 
 	// another reconcile should not stop the pending instances
 	for i := 0; i < 2; i++ {
@@ -462,6 +772,224 @@ func TestReconciler_RollingUpgradeX(t *testing.T) {
 	})
 }
 
+func TestReconciler_RollingUpgrade_MaxUnavailable(t *testing.T) {
+	// the disruption budget limits the number of parallel updates
+	spec0 := mockClusterSpec()
+	spec0.Groups[0].Count = 5
+	spec0.Groups[0].MaxUnavailable = 1
+
+	spec1 := spec0.Copy()
+	spec1.Sequence++
+	spec1.Groups[0].Resources = schema.MapToSpec(map[string]interface{}{"A": "B"})
+
+	dep := newMockDeployment()
+	for i := 0; i < 5; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec0.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec1,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+	})
+}
+
+func TestReconciler_RollingUpgrade_PauseBelowQuorum(t *testing.T) {
+	// a rolling update does not stop healthy instances if another
+	// instance is being rescheduled and the quorum would be lost
+	spec0 := mockClusterSpec()
+	spec0.Groups[0].Count = 3
+	spec0.Groups[0].MinAvailable = 2
+
+	spec1 := spec0.Copy()
+	spec1.Sequence++
+	spec1.Groups[0].Resources = schema.MapToSpec(map[string]interface{}{"A": "B"})
+
+	dep := newMockDeployment()
+	for i := 0; i < 3; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec0.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	// one instance has failed and it is rescheduled
+	dep.Instances[0].Status = proto.Instance_STOPPED
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec1,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		reschedule: 1,
+		out:        1,
+		stop:       0,
+		done:       false,
+	})
+}
+
+func TestReconciler_Restart(t *testing.T) {
+	// a restart replaces the instances of the group
+	// without any change in the spec
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+
+	dep := newMockDeployment()
+	for i := 0; i < 3; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		done: true,
+	})
+
+	dep.Restarts = map[string]int64{"": 1}
+
+	rec.Compute()
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 2,
+	})
+	for _, i := range rec.res.stop {
+		assert.True(t, i.update)
+	}
+
+	// instances created after the restart are not replaced
+	for _, i := range dep.Instances {
+		i.Restart = 1
+	}
+
+	rec.Compute()
+	testExpectReconcile(t, rec, expectedReconciler{
+		done: true,
+	})
+}
+
+func TestReconciler_ReplaceInstance(t *testing.T) {
+	// only the instance marked for replacement is stopped
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+
+	dep := newMockDeployment()
+	for i := 0; i < 3; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+	dep.Instances[1].Replace = true
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+	})
+	assert.Equal(t, rec.res.stop[0].instance.ID, dep.Instances[1].ID)
+	assert.True(t, rec.res.stop[0].update)
+}
+
+func TestReconciler_ReplaceUnhealthy(t *testing.T) {
+	// the instance is replaced once it is unhealthy for longer than the policy
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+	spec.Groups[0].ReplaceUnhealthySeconds = 60
+
+	now := time.Now()
+	since, _ := ptypes.TimestampProto(now)
+
+	dep := newMockDeployment()
+	for i := 0; i < 2; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Name = fmt.Sprintf("a-%d", i)
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+	dep.Instances[1].Healthy = false
+	dep.Instances[1].HealthError = "health check failed"
+	dep.Instances[1].UnhealthySince = since
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+		now:  now.Add(30 * time.Second),
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{})
+	assert.Equal(t, blockedMessages(rec), []string{"unhealthy instance a-1"})
+
+	rec.now = now.Add(time.Minute)
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		stop: 1,
+	})
+	assert.Equal(t, rec.res.stop[0].instance.ID, dep.Instances[1].ID)
+	assert.True(t, rec.res.stop[0].update)
+	assert.Equal(t, blockedMessages(rec), []string{"replacing unhealthy instance a-1"})
+}
+
+func TestReconciler_RollingUpgrade_Reschedule(t *testing.T) {
+	// the failed instance is rescheduled, it is not stopped by the rolling update
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 3
+
+	dep := newMockDeployment()
+	for i := 0; i < 3; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+	dep.Instances[0].Status = proto.Instance_STOPPED
+	dep.Instances[0].Replace = true
+
+	rec := &reconciler{
+		dep:  dep.Deployment,
+		spec: spec,
+	}
+	rec.Compute()
+
+	testExpectReconcile(t, rec, expectedReconciler{
+		reschedule: 1,
+		out:        1,
+	})
+}
+
 func TestReconciler_RollingUpgrade_SecondEval(t *testing.T) {
 	// Second evaluation for the rolling update
 	spec0 := mockClusterSpec()
@@ -692,6 +1220,7 @@ func TestReconciler_InstanceFailed_Restart(t *testing.T) {
 
 	testExpectReconcile(t, rec, expectedReconciler{
 		reschedule: 1,
+		out:        1,
 	})
 }
 
@@ -758,6 +1287,7 @@ func TestReconciler_InstanceFailed_ScaleDown(t *testing.T) {
 	testExpectReconcile(t, rec, expectedReconciler{
 		stop:       2,
 		reschedule: 1,
+		out:        1,
 	})
 
 	assert.Equal(t, rec.res.stop[0].instance.ID, dep.Instances[2].ID)
@@ -1,7 +1,12 @@
 package operator
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
 )
@@ -10,6 +15,7 @@ type schedState interface {
 	LoadDeployment(id string) (*proto.Deployment, error)
 	GetComponentByID(deployment string, id string, sequence int64) (*proto.Component, error)
 	GetHandler(id string) (Handler, error)
+	ResolveOutput(ref *Reference) (string, error)
 }
 
 type Scheduler interface {
@@ -49,6 +55,12 @@ func (s *scheduler) Process(eval *proto.Evaluation) (*proto.Plan, error) {
 	// we need this here because is not set before in the spec
 	spec.Sequence = comp.Sequence
 
+	// the references to the outputs of other clusters are resolved on each
+	// evaluation. If any of them changes, the instances are updated.
+	if err := resolveReferences(spec, s.state.ResolveOutput); err != nil {
+		return nil, err
+	}
+
 	r := &reconciler{
 		delete: comp.Action == proto.Component_DELETE,
 		dep:    dep,
@@ -91,23 +103,36 @@ func (s *scheduler) Process(eval *proto.Evaluation) (*proto.Plan, error) {
 		// create a cluster object to initialize the instances
 		placeInstances := []*proto.Instance{}
 		for _, i := range r.res.place {
-			var name string
+			ii := &proto.Instance{}
 			if i.instance == nil {
-				name = i.name
+				ii.Name = i.name
+				ii.Ordinal = i.ordinal
 			} else {
-				name = i.instance.Name
+				// keep the identity and the volumes of the instance being replaced
+				ii.Name = i.instance.Name
+				ii.Ordinal = instanceOrdinal(i.instance)
+				ii.Prev = i.instance.ID
+				for _, m := range i.instance.Mounts {
+					m = m.Copy()
+					if i.instance.Wipe {
+						// use a new volume
+						m.Id = uuid.UUID8()
+					}
+					ii.Mounts = append(ii.Mounts, m)
+				}
+				if i.reschedule {
+					ii.Reschedule = i.instance.Reschedule
+				}
 			}
-
-			ii := &proto.Instance{}
 			ii.ID = uuid.UUID()
 			ii.Group = i.group
 			ii.Spec = &proto.NodeSpec{}
 			ii.ClusterName = dep.Name
 			ii.DeploymentID = dep.Id
 			ii.DnsSuffix = dep.DnsSuffix
-			ii.Name = name
 			ii.Status = proto.Instance_PENDING
 			ii.Canary = i.update
+			ii.Restart = dep.Restarts[i.group.Type]
 
 			placeInstances = append(placeInstances, ii)
 		}
@@ -123,14 +148,84 @@ func (s *scheduler) Process(eval *proto.Evaluation) (*proto.Plan, error) {
 		plan.Done = true
 		plan.Status = proto.DeploymentCompleted
 	} else if r.res.done {
-		if dep.Status != proto.DeploymentDone {
+		plan.Status = proto.DeploymentDone
+		if dep.Status != proto.DeploymentDone && !comp.IsDone() {
+			// finalize the component only once (i.e. a restart
+			// after the component is applied)
 			plan.Done = true
-			plan.Status = proto.DeploymentDone
 		}
 	} else {
 		plan.Status = proto.DeploymentRunning
+		plan.Blocked = r.res.blocked
+
+		// the reconciler keeps working on a failed deployment
+		// in case it can still converge
+		plan.Conditions = failureConditions(dep, spec, r.res, time.Now())
+		if len(plan.Conditions) != 0 {
+			plan.Status = proto.DeploymentFailed
+
+			msgs := []string{}
+			for _, c := range plan.Conditions {
+				msgs = append(msgs, c.Message)
+			}
+			plan.Reason = strings.Join(msgs, "; ")
+		}
 	}
 
 	plan.Deployment = dep
 	return plan, nil
 }
+
+// failureConditions returns the conditions that make the deployment fail. The
+// timestamp of a condition that was already set in the deployment is kept.
+func failureConditions(dep *proto.Deployment, spec *proto.ClusterSpec, res *reconcileResult, now time.Time) []*proto.Deployment_Condition {
+	conds := []*proto.Deployment_Condition{}
+
+	if len(res.lost) != 0 {
+		names := []string{}
+		for _, i := range res.lost {
+			names = append(names, i.Name)
+		}
+		conds = append(conds, &proto.Deployment_Condition{
+			Type:    proto.ConditionInstancesLost,
+			Message: fmt.Sprintf("instances lost after %d attempts: %s", maxAttempts, strings.Join(names, ", ")),
+		})
+	}
+
+	unhealthy := []string{}
+	for _, i := range dep.Instances {
+		if i.Status == proto.Instance_RUNNING && i.DesiredStatus == proto.Instance_RUN && i.HealthError != "" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", i.Name, i.HealthError))
+		}
+	}
+	if len(unhealthy) != 0 {
+		conds = append(conds, &proto.Deployment_Condition{
+			Type:    proto.ConditionInstancesUnhealthy,
+			Message: fmt.Sprintf("unhealthy instances: %s", strings.Join(unhealthy, ", ")),
+		})
+	}
+
+	if spec.ProgressDeadlineSeconds != 0 && dep.ProgressStart != nil {
+		start, err := ptypes.Timestamp(dep.ProgressStart)
+		if err == nil {
+			deadline := time.Duration(spec.ProgressDeadlineSeconds) * time.Second
+			if now.After(start.Add(deadline)) {
+				conds = append(conds, &proto.Deployment_Condition{
+					Type:    proto.ConditionProgressDeadline,
+					Message: fmt.Sprintf("deployment did not finish in %s", deadline),
+				})
+			}
+		}
+	}
+
+	ts, _ := ptypes.TimestampProto(now)
+	for _, c := range conds {
+		c.Timestamp = ts
+		for _, prev := range dep.Conditions {
+			if prev.Type == c.Type {
+				c.Timestamp = prev.Timestamp
+			}
+		}
+	}
+	return conds
+}
@@ -1,8 +1,11 @@
 package operator
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
@@ -42,3 +45,280 @@ func TestScheduler_EvalInstanceFailed(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, plan.NodeUpdate[0].Name, dep.Instances[0].Name)
 }
+
+func TestScheduler_RescheduleKeepsIdentity(t *testing.T) {
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+
+	dep := newMockDeployment()
+	for i := 1; i <= 2; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Name = fmt.Sprintf("a-%d", i)
+		ii.Ordinal = int64(i)
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		ii.Mounts = []*proto.Instance_Mount{
+			{Name: "data", Path: "/data"},
+		}
+		dep.Instances = append(dep.Instances, ii)
+	}
+	// one instance has failed
+	dep.Instances[1].Status = proto.Instance_STOPPED
+	dep.CompId = "a"
+
+	harness := NewHarness(t)
+	harness.Deployment = dep.Deployment
+	harness.Handler = &nullHandler{}
+
+	harness.AddComponent(&proto.Component{
+		Id:   "a",
+		Spec: proto.MustMarshalAny(spec),
+	})
+
+	sched := NewScheduler(harness)
+
+	plan, err := sched.Process(&proto.Evaluation{
+		Id: uuid.UUID(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, plan.NodeUpdate, 2)
+
+	// the failed instance is out
+	out := plan.NodeUpdate[0]
+	assert.Equal(t, out.ID, dep.Instances[1].ID)
+	assert.Equal(t, out.Status, proto.Instance_OUT)
+
+	// the replacement keeps the name, ordinal and volumes
+	place := plan.NodeUpdate[1]
+	assert.Equal(t, place.Status, proto.Instance_PENDING)
+	assert.Equal(t, place.Prev, dep.Instances[1].ID)
+	assert.Equal(t, place.Name, "a-2")
+	assert.Equal(t, place.Ordinal, int64(2))
+	assert.Len(t, place.Mounts, 1)
+	assert.Equal(t, place.Mounts[0].Name, "data")
+}
+
+func testSchedulerProcess(t *testing.T, spec *proto.ClusterSpec, dep *mockDeployment) *proto.Plan {
+	dep.CompId = "a"
+
+	harness := NewHarness(t)
+	harness.Deployment = dep.Deployment
+	harness.Handler = &nullHandler{}
+
+	harness.AddComponent(&proto.Component{
+		Id:   "a",
+		Spec: proto.MustMarshalAny(spec),
+	})
+
+	plan, err := NewScheduler(harness).Process(&proto.Evaluation{
+		Id: uuid.UUID(),
+	})
+	assert.NoError(t, err)
+	return plan
+}
+
+func TestScheduler_DeploymentFailed_InstancesLost(t *testing.T) {
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+
+	dep := newMockDeployment()
+	for i := 0; i < 2; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_RUNNING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		ii.Healthy = true
+		dep.Instances = append(dep.Instances, ii)
+	}
+	// one instance cannot be rescheduled anymore
+	dep.Instances[0].Status = proto.Instance_STOPPED
+	dep.Instances[0].Reschedule = &proto.Instance_Reschedule{
+		Attempts: maxAttempts,
+	}
+
+	plan := testSchedulerProcess(t, spec, dep)
+	assert.Equal(t, plan.Status, proto.DeploymentFailed)
+	assert.False(t, plan.Done)
+	assert.NotEmpty(t, plan.Reason)
+	assert.Len(t, plan.Conditions, 1)
+	assert.Equal(t, plan.Conditions[0].Type, proto.ConditionInstancesLost)
+
+	// the plan explains why the deployment does not converge
+	if assert.Len(t, plan.Blocked, 1) {
+		assert.Equal(t, plan.Blocked[0].Message, "reschedule attempts exhausted for instance "+dep.Instances[0].ID)
+	}
+}
+
+func TestScheduler_DeploymentFailed_Unhealthy(t *testing.T) {
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 1
+
+	dep := newMockDeployment()
+	dep.Instances = append(dep.Instances, &proto.Instance{
+		ID:          uuid.UUID(),
+		Name:        "a",
+		Status:      proto.Instance_RUNNING,
+		Group:       spec.Groups[0],
+		HealthError: "startup probe failed: timeout",
+	})
+
+	plan := testSchedulerProcess(t, spec, dep)
+	assert.Equal(t, plan.Status, proto.DeploymentFailed)
+	assert.Equal(t, plan.Reason, "unhealthy instances: a (startup probe failed: timeout)")
+	if assert.Len(t, plan.Blocked, 1) {
+		assert.Equal(t, plan.Blocked[0].Message, "unhealthy instance a")
+	}
+}
+
+func TestScheduler_DeploymentFailed_ProgressDeadline(t *testing.T) {
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 2
+	spec.ProgressDeadlineSeconds = 60
+
+	dep := newMockDeployment()
+	for i := 0; i < 2; i++ {
+		ii := &proto.Instance{}
+		ii.Status = proto.Instance_PENDING
+		ii.ID = uuid.UUID()
+		ii.Group = spec.Groups[0]
+		dep.Instances = append(dep.Instances, ii)
+	}
+
+	// the deployment is still within the deadline
+	dep.ProgressStart = ptypes.TimestampNow()
+
+	plan := testSchedulerProcess(t, spec, dep)
+	assert.Equal(t, plan.Status, proto.DeploymentRunning)
+	assert.Empty(t, plan.Conditions)
+
+	// the deadline has expired
+	dep.ProgressStart, _ = ptypes.TimestampProto(time.Now().Add(-2 * time.Minute))
+
+	plan = testSchedulerProcess(t, spec, dep)
+	assert.Equal(t, plan.Status, proto.DeploymentFailed)
+	assert.Len(t, plan.Conditions, 1)
+	assert.Equal(t, plan.Conditions[0].Type, proto.ConditionProgressDeadline)
+}
+
+func TestScheduler_DeploymentDone_KeepStatus(t *testing.T) {
+	// an evaluation on a deployment that is already done
+	// does not finalize it again
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 1
+
+	dep := newMockDeployment()
+	dep.Status = proto.DeploymentDone
+	dep.Instances = append(dep.Instances, &proto.Instance{
+		ID:      uuid.UUID(),
+		Status:  proto.Instance_RUNNING,
+		Group:   spec.Groups[0],
+		Healthy: true,
+	})
+
+	plan := testSchedulerProcess(t, spec, dep)
+	assert.Equal(t, plan.Status, proto.DeploymentDone)
+	assert.False(t, plan.Done)
+}
+
+func TestScheduler_Restart(t *testing.T) {
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 1
+
+	dep := newMockDeployment()
+	dep.Status = proto.DeploymentRunning
+	dep.Restarts = map[string]int64{"": 1}
+
+	// the instance being replaced is already stopped
+	dep.Instances = append(dep.Instances, &proto.Instance{
+		ID:            uuid.UUID(),
+		Name:          "a-1",
+		Status:        proto.Instance_STOPPED,
+		DesiredStatus: proto.Instance_STOP,
+		Canary:        true,
+		Group:         spec.Groups[0],
+	})
+
+	plan := testSchedulerProcess(t, spec, dep)
+	assert.Len(t, plan.NodeUpdate, 2)
+
+	place := plan.NodeUpdate[1]
+	assert.Equal(t, place.Name, "a-1")
+	assert.Equal(t, place.Restart, int64(1))
+}
+
+func TestScheduler_Restart_NoFinalize(t *testing.T) {
+	// the component was applied before the restart so
+	// it does not have to be finalized again
+	spec := mockClusterSpec()
+	spec.Groups[0].Count = 1
+
+	dep := newMockDeployment()
+	dep.Status = proto.DeploymentRunning
+	dep.Instances = append(dep.Instances, &proto.Instance{
+		ID:      uuid.UUID(),
+		Status:  proto.Instance_RUNNING,
+		Group:   spec.Groups[0],
+		Healthy: true,
+	})
+	dep.CompId = "a"
+
+	harness := NewHarness(t)
+	harness.Deployment = dep.Deployment
+	harness.Handler = &nullHandler{}
+
+	harness.AddComponent(&proto.Component{
+		Id:     "a",
+		Spec:   proto.MustMarshalAny(spec),
+		Status: proto.Component_APPLIED,
+	})
+
+	plan, err := NewScheduler(harness).Process(&proto.Evaluation{
+		Id: uuid.UUID(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, plan.Status, proto.DeploymentDone)
+	assert.False(t, plan.Done)
+}
+
+func TestScheduler_ReplaceInstance_Wipe(t *testing.T) {
+	for _, wipe := range []bool{false, true} {
+		spec := mockClusterSpec()
+		spec.Groups[0].Count = 1
+
+		dep := newMockDeployment()
+		dep.Status = proto.DeploymentRunning
+
+		// the instance marked for replacement is already stopped
+		dep.Instances = append(dep.Instances, &proto.Instance{
+			ID:            uuid.UUID(),
+			Name:          "a-1",
+			Ordinal:       1,
+			Status:        proto.Instance_STOPPED,
+			DesiredStatus: proto.Instance_STOP,
+			Canary:        true,
+			Replace:       true,
+			Wipe:          wipe,
+			Group:         spec.Groups[0],
+			Mounts: []*proto.Instance_Mount{
+				{Name: "data", Path: "/data"},
+			},
+		})
+		old := dep.Instances[0]
+
+		plan := testSchedulerProcess(t, spec, dep)
+		assert.Len(t, plan.NodeUpdate, 2)
+
+		place := plan.NodeUpdate[1]
+		assert.Equal(t, place.Name, "a-1")
+		assert.Equal(t, place.Ordinal, int64(1))
+		assert.False(t, place.Replace)
+
+		if wipe {
+			assert.NotEqual(t, place.VolumeName(place.Mounts[0]), old.VolumeName(old.Mounts[0]))
+		} else {
+			assert.Equal(t, place.VolumeName(place.Mounts[0]), old.VolumeName(old.Mounts[0]))
+		}
+	}
+}
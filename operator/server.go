@@ -10,11 +10,12 @@ import (
 	"time"
 
 	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
-	"github.com/teseraio/ensemble/operator/state/boltdb"
+	"github.com/teseraio/ensemble/operator/state"
 	"google.golang.org/grpc"
 )
 
@@ -24,13 +25,20 @@ type Config struct {
 	Provider Provider
 
 	// State is the state access
-	State *boltdb.BoltDB
+	State state.State
 
 	// Backends are the list of backends handled by the operator
 	HandlerFactories []HandlerFactory
 
 	// GRPCAddr is the address of the grpc server
 	GRPCAddr *net.TCPAddr
+
+	// Retention is the policy to compact the state. The state
+	// is not compacted if it is not set.
+	Retention *state.RetentionPolicy
+
+	// CompactInterval is the interval between compactions of the state
+	CompactInterval time.Duration
 }
 
 // Server is the operator server
@@ -39,7 +47,7 @@ type Server struct {
 	logger hclog.Logger
 
 	Provider Provider
-	State    *boltdb.BoltDB
+	State    state.State
 
 	handlers   map[string]Handler
 	grpcServer *grpc.Server
@@ -48,9 +56,19 @@ type Server struct {
 	evalQueue *evalQueue
 	service   proto.EnsembleServiceServer
 
-	// subscriptions
+	// lock serializes the writes of the instances and the plans
 	lock sync.Mutex
-	subs []chan *InstanceUpdate
+
+	// events notifies the updates of the instances
+	events *eventBus
+
+	// unhealthyTimers evaluates the deployments once their
+	// unhealthy instances have to be replaced
+	unhealthyTimers timerSet
+
+	// progressTimers evaluates the deployments once the progress
+	// deadline of their rollout expires
+	progressTimers timerSet
 }
 
 // NewServer starts an instance of the operator server
@@ -63,7 +81,11 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		stopCh:    make(chan struct{}),
 		handlers:  map[string]Handler{},
 		evalQueue: newEvalQueue(),
-		subs:      []chan *InstanceUpdate{},
+	}
+	s.events = newEventBus(defaultEventBufferSize, s.instanceIDs)
+
+	if err := s.cancelPendingEvals(); err != nil {
+		return nil, err
 	}
 
 	for _, factory := range config.HandlerFactories {
@@ -85,8 +107,8 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 	s.Provider.Setup(s)
 
 	// setup watcher for the different backends
-	for _, i := range s.handlers {
-		i.Setup(s)
+	for name, i := range s.handlers {
+		i.Setup(s.backendControlPlane(name))
 	}
 
 	s.logger.Info("Start provider")
@@ -97,7 +119,17 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 	go s.taskQueue4()
 	go s.taskQueue5()
 
+	// the timers of the previous run of the operator are lost
+	if err := s.restoreProgressTimers(); err != nil {
+		return nil, err
+	}
+	if err := s.restoreUnhealthyTimers(); err != nil {
+		return nil, err
+	}
+
 	go s.instanceWatcher()
+	go s.autoscaler()
+	go s.compactor()
 
 	return s, nil
 }
@@ -116,9 +148,17 @@ func (s *Server) loggingServerInterceptor(ctx context.Context, req interface{},
 func (s *Server) instanceWatcher() {
 	//fmt.Println("INSTANCE WATCHER START")
 
-	stream := s.SubscribeInstanceUpdates()
+	sub := s.SubscribeInstanceUpdates()
+	defer sub.Close()
+
 	for {
-		msg := <-stream
+		msg, err := sub.Next(context.Background())
+		if err != nil {
+			if err != ErrSubscriptionClosed {
+				s.logger.Error("failed to read instance update", "err", err)
+			}
+			return
+		}
 		if err := s.handleInstanceUpdate(msg); err != nil {
 			s.logger.Error("failed to handle instance update", "err", err)
 		}
@@ -128,21 +168,39 @@ func (s *Server) instanceWatcher() {
 func (s *Server) handleInstanceUpdate(msg *InstanceUpdate) error {
 	instance, err := s.GetInstance(msg.InstanceID)
 	if err != nil {
+		// the instance might have been removed
+		s.unhealthyTimers.cancel(msg.InstanceID)
 		return err
 	}
+	if err := s.scheduleUnhealthyReplace(instance); err != nil {
+		s.logger.Error("failed to schedule the replace of the unhealthy instance", "id", instance.ID, "err", err)
+	}
 	if instance.Status == proto.Instance_RUNNING || instance.Status == proto.Instance_STOPPED {
+		if s.isPaused(instance.DeploymentID) {
+			// the instance will be evaluated on resume
+			return nil
+		}
+
 		// add eval
 
 		if instance.Status == proto.Instance_STOPPED && instance.DesiredStatus == proto.Instance_RUN {
-			// update the deployment to running in case it is not already
+			// update the deployment to running in case it is not already. A failed
+			// deployment is kept as failed until the scheduler says otherwise
 			dep, err := s.LoadDeployment(instance.DeploymentID)
 			if err != nil {
 				return err
 			}
-			if dep.Status != proto.DeploymentRunning {
+			if dep.Status != proto.DeploymentRunning && dep.Status != proto.DeploymentFailed {
 				dep = dep.Copy()
 				dep.Status = proto.DeploymentRunning
-				if err := s.State.UpdateDeployment(dep); err != nil {
+
+				deadline, err := s.progressDeadline(dep)
+				if err != nil {
+					return err
+				}
+				s.startProgress(dep, deadline)
+
+				if err := s.updateDeployment(dep); err != nil {
 					return err
 				}
 			}
@@ -155,7 +213,7 @@ func (s *Server) handleInstanceUpdate(msg *InstanceUpdate) error {
 			DeploymentID: instance.DeploymentID,
 			Type:         proto.EvaluationTypeCluster,
 		}
-		s.evalQueue.add(eval)
+		s.addEval(eval)
 	}
 	return nil
 }
@@ -183,12 +241,20 @@ func (s *Server) Config() *Config {
 // Stop stops the server
 func (s *Server) Stop() {
 	s.grpcServer.Stop()
+	s.events.close()
+	s.unhealthyTimers.stop()
+	s.progressTimers.stop()
 	close(s.stopCh)
 }
 
-// Exec implements the Activator interface
+// Logger implements the LoggerProvider interface
+func (s *Server) Logger() hclog.Logger {
+	return s.logger
+}
+
+// Exec implements the Executor interface
 func (s *Server) Exec(n *proto.Instance, path string, cmd ...string) (string, error) {
-	return s.Provider.Exec("n.Handle", path, cmd...)
+	return s.Provider.Exec(n.ID, path, cmd...)
 }
 
 func (s *Server) taskQueue5() {
@@ -234,7 +300,7 @@ func (s *Server) handleResource(task *proto.Task, dep *proto.Deployment, cluster
 	}
 
 	// create a specChange evaluation
-	s.evalQueue.add(&proto.Evaluation{
+	s.addEval(&proto.Evaluation{
 		Id:           uuid.UUID(),
 		Status:       proto.Evaluation_PENDING,
 		TriggeredBy:  proto.Evaluation_SPECCHANGE,
@@ -270,13 +336,15 @@ func (s *Server) handleCluster(task *proto.Task, dep *proto.Deployment, comp *pr
 	dep.Status = proto.DeploymentRunning
 	dep.Sequence = comp.Sequence
 	dep.CompId = task.ComponentID
+	dep.PriorityClass = spec.PriorityClass
+	s.startProgress(dep, spec.ProgressDeadlineSeconds)
 
-	if err := s.State.UpdateDeployment(dep); err != nil {
+	if err := s.updateDeployment(dep); err != nil {
 		return err
 	}
 
 	// create a specChange evaluation
-	s.evalQueue.add(&proto.Evaluation{
+	s.addEval(&proto.Evaluation{
 		Id:           uuid.UUID(),
 		Status:       proto.Evaluation_PENDING,
 		TriggeredBy:  proto.Evaluation_SPECCHANGE,
@@ -288,6 +356,424 @@ func (s *Server) handleCluster(task *proto.Task, dep *proto.Deployment, comp *pr
 	return nil
 }
 
+// currentSpec returns the cluster spec applied in the deployment
+func (s *Server) currentSpec(dep *proto.Deployment) (*proto.ClusterSpec, error) {
+	comp, err := s.State.GetComponentByID2(dep.Id, dep.CompId, dep.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	var spec proto.ClusterSpec
+	if err := gproto.Unmarshal(comp.Spec.Value, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// progressDeadline returns the progress deadline (in seconds) of the
+// current spec of the deployment
+func (s *Server) progressDeadline(dep *proto.Deployment) (int64, error) {
+	spec, err := s.currentSpec(dep)
+	if err != nil {
+		return 0, err
+	}
+	return spec.ProgressDeadlineSeconds, nil
+}
+
+// scheduleUnhealthyReplace schedules an evaluation for when the unhealthy instance
+// has to be replaced following the policy of its group. There is only one timer
+// for each instance and it is cancelled if the instance recovers or stops.
+func (s *Server) scheduleUnhealthyReplace(instance *proto.Instance) error {
+	if instance.Status != proto.Instance_RUNNING || instance.DesiredStatus == proto.Instance_STOP || instance.UnhealthySince == nil {
+		s.unhealthyTimers.cancel(instance.ID)
+		return nil
+	}
+	dep, err := s.LoadDeployment(instance.DeploymentID)
+	if err != nil {
+		return err
+	}
+	if dep == nil {
+		return fmt.Errorf("deployment does not exists '%s'", instance.DeploymentID)
+	}
+	spec, err := s.currentSpec(dep)
+	if err != nil {
+		return err
+	}
+	var replaceSeconds int64
+	for _, grp := range spec.Groups {
+		if grp.Type == instance.Group.GetType() {
+			replaceSeconds = grp.ReplaceUnhealthySeconds
+		}
+	}
+	if replaceSeconds == 0 {
+		s.unhealthyTimers.cancel(instance.ID)
+		return nil
+	}
+	since, err := ptypes.Timestamp(instance.UnhealthySince)
+	if err != nil {
+		return err
+	}
+
+	depID := dep.Id
+	s.unhealthyTimers.set(instance.ID, time.Until(since.Add(time.Duration(replaceSeconds)*time.Second)), func() {
+		s.addEval(&proto.Evaluation{
+			Id:           uuid.UUID(),
+			Status:       proto.Evaluation_PENDING,
+			TriggeredBy:  proto.Evaluation_NODECHANGE,
+			DeploymentID: depID,
+			Type:         proto.EvaluationTypeCluster,
+		})
+	})
+	return nil
+}
+
+// restoreUnhealthyTimers schedules again the replace of the instances
+// that were already unhealthy when the operator starts
+func (s *Server) restoreUnhealthyTimers() error {
+	ids, err := s.instanceIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		instance, err := s.GetInstance(id)
+		if err != nil {
+			s.logger.Error("failed to load the instance", "id", id, "err", err)
+			continue
+		}
+		if err := s.scheduleUnhealthyReplace(instance); err != nil {
+			s.logger.Error("failed to schedule the replace of the unhealthy instance", "id", instance.ID, "err", err)
+		}
+	}
+	return nil
+}
+
+// startProgress resets the progress time of the deployment and schedules
+// an evaluation for when the deadline expires. It replaces the timer of
+// any previous rollout of the deployment.
+func (s *Server) startProgress(dep *proto.Deployment, deadline int64) {
+	dep.ProgressStart = ptypes.TimestampNow()
+	if deadline == 0 {
+		s.progressTimers.cancel(dep.Id)
+		return
+	}
+
+	s.setProgressTimer(dep.Id, time.Duration(deadline)*time.Second)
+}
+
+// setProgressTimer evaluates the deployment once the progress deadline expires
+func (s *Server) setProgressTimer(depID string, d time.Duration) {
+	s.progressTimers.set(depID, d, func() {
+		s.addEval(&proto.Evaluation{
+			Id:           uuid.UUID(),
+			Status:       proto.Evaluation_PENDING,
+			TriggeredBy:  proto.Evaluation_DEADLINE,
+			DeploymentID: depID,
+			Type:         proto.EvaluationTypeCluster,
+		})
+	})
+}
+
+// restoreProgressTimers sets again the progress timers of the rollouts in
+// progress when the operator starts with the time left to their deadlines
+func (s *Server) restoreProgressTimers() error {
+	deps, err := s.State.ListDeployments()
+	if err != nil {
+		return err
+	}
+	for _, d := range deps {
+		dep, err := s.State.LoadDeployment(d.Id)
+		if err != nil {
+			return err
+		}
+		if dep == nil || dep.Status != proto.DeploymentRunning || dep.ProgressStart == nil {
+			continue
+		}
+		deadline, err := s.progressDeadline(dep)
+		if err != nil {
+			return err
+		}
+		if deadline == 0 {
+			continue
+		}
+		start, err := ptypes.Timestamp(dep.ProgressStart)
+		if err != nil {
+			return err
+		}
+		left := time.Until(start.Add(time.Duration(deadline) * time.Second))
+		if left < 0 {
+			left = 0
+		}
+		s.setProgressTimer(dep.Id, left)
+	}
+	return nil
+}
+
+// Scale changes the number of instances of a group in the deployment. It applies
+// a new version of the latest cluster spec with only the count of the group modified.
+// The group can be empty if the deployment has only one.
+func (s *Server) Scale(deploymentID, group string, count int64) (*proto.Component, error) {
+	latest, spec, err := s.latestClusterSpec(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	var grp *proto.ClusterSpec_Group
+	if group == "" {
+		// the group is only optional if there is one
+		if len(spec.Groups) != 1 {
+			types := []string{}
+			for _, g := range spec.Groups {
+				types = append(types, fmt.Sprintf("'%s'", g.Type))
+			}
+			return nil, fmt.Errorf("the deployment has %d groups (%s), the group to scale is required", len(spec.Groups), strings.Join(types, ", "))
+		}
+		grp = spec.Groups[0]
+	} else {
+		for _, g := range spec.Groups {
+			if g.Type == group {
+				grp = g
+			}
+		}
+		if grp == nil {
+			return nil, fmt.Errorf("group '%s' not found", group)
+		}
+	}
+	grp.Count = count
+
+	// fail if another version is applied in the meantime
+	component := &proto.Component{
+		Name:             latest.Name,
+		Spec:             proto.MustMarshalAny(spec),
+		Metadata:         latest.Metadata,
+		ExpectedSequence: latest.Sequence,
+	}
+	if component, err = s.validateComponent(component); err != nil {
+		return nil, err
+	}
+	return s.State.Apply(component)
+}
+
+// latestClusterSpec returns the last version applied of the cluster
+// component of the deployment
+func (s *Server) latestClusterSpec(deploymentID string) (*proto.Component, *proto.ClusterSpec, error) {
+	comp, err := s.State.ReadDeployment(deploymentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comp == nil {
+		return nil, nil, fmt.Errorf("deployment does not exists '%s'", deploymentID)
+	}
+	versions, err := s.State.GetComponentVersions(deploymentID, comp.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	latest := versions[len(versions)-1]
+	if latest.Action == proto.Component_DELETE {
+		return nil, nil, fmt.Errorf("deployment '%s' is deleted", deploymentID)
+	}
+
+	var spec proto.ClusterSpec
+	if err := gproto.Unmarshal(latest.Spec.Value, &spec); err != nil {
+		return nil, nil, err
+	}
+	return latest, &spec, nil
+}
+
+// Restore restores the state of the operator from a snapshot. The state
+// of the operator must be empty.
+func (s *Server) Restore(snap *proto.Snapshot) error {
+	if snap.Version != state.SnapshotVersion {
+		return fmt.Errorf("snapshot version %d not supported, expected %d", snap.Version, state.SnapshotVersion)
+	}
+	return s.State.Restore(snap)
+}
+
+// Purge removes a deleted deployment and all its history
+func (s *Server) Purge(deploymentID string) error {
+	if err := s.State.Purge(deploymentID); err != nil {
+		return err
+	}
+	s.progressTimers.cancel(deploymentID)
+	s.logger.Info("Deployment purged", "id", deploymentID)
+	return nil
+}
+
+// DependencyGraph returns the deployments and the dependencies between them
+func (s *Server) DependencyGraph() (*proto.DependencyGraph, error) {
+	deps, err := s.State.ListDeployments()
+	if err != nil {
+		return nil, err
+	}
+	graph := &proto.DependencyGraph{
+		Nodes: []*proto.DependencyGraph_Node{},
+	}
+	for _, dep := range deps {
+		comp, err := s.State.ReadDeployment(dep.Id)
+		if err != nil {
+			return nil, err
+		}
+		dependsOn, _, err := s.State.GetDependencies(dep.Id)
+		if err != nil {
+			return nil, err
+		}
+		node := &proto.DependencyGraph_Node{
+			Id:        dep.Id,
+			Name:      dep.Name,
+			DependsOn: dependsOn,
+		}
+		if comp != nil {
+			node.Name = comp.Name
+			node.Deleted = comp.Action == proto.Component_DELETE
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	return graph, nil
+}
+
+// Pause stops the reconciliation of a deployment. The instances are not
+// modified and the new components stay pending until the deployment is resumed.
+func (s *Server) Pause(deploymentID string) (*proto.Deployment, error) {
+	return s.State.SetPaused(deploymentID, true)
+}
+
+// Resume resumes the reconciliation of a paused deployment
+func (s *Server) Resume(deploymentID string) (*proto.Deployment, error) {
+	dep, err := s.State.SetPaused(deploymentID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// evaluate any change done while the deployment was paused
+	if dep.CompId != "" {
+		s.addEval(&proto.Evaluation{
+			Id:           uuid.UUID(),
+			Status:       proto.Evaluation_PENDING,
+			TriggeredBy:  proto.Evaluation_RESUME,
+			DeploymentID: deploymentID,
+			Type:         proto.EvaluationTypeCluster,
+		})
+	}
+	return dep, nil
+}
+
+func (s *Server) isPaused(deploymentID string) bool {
+	dep, err := s.LoadDeployment(deploymentID)
+	if err != nil || dep == nil {
+		return false
+	}
+	return dep.Paused
+}
+
+// Restart starts a rolling restart of the instances of a deployment. If group
+// is empty, all the groups in the deployment are restarted.
+func (s *Server) Restart(deploymentID, group string) (*proto.Deployment, error) {
+	dep, err := s.LoadDeployment(deploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if dep == nil || dep.CompId == "" {
+		return nil, fmt.Errorf("deployment does not exists '%s'", deploymentID)
+	}
+	comp, err := s.State.GetComponentByID2(dep.Id, dep.CompId, dep.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	if comp.Action == proto.Component_DELETE {
+		return nil, fmt.Errorf("deployment '%s' is being deleted", deploymentID)
+	}
+	var spec proto.ClusterSpec
+	if err := gproto.Unmarshal(comp.Spec.Value, &spec); err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	for _, grp := range spec.Groups {
+		if group == "" || grp.Type == group {
+			groups = append(groups, grp.Type)
+		}
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group '%s' not found", group)
+	}
+
+	dep = dep.Copy()
+	dep.Instances = nil
+	if dep.Restarts == nil {
+		dep.Restarts = map[string]int64{}
+	}
+	for _, typ := range groups {
+		dep.Restarts[typ]++
+	}
+	if dep.Status != proto.DeploymentFailed {
+		dep.Status = proto.DeploymentRunning
+	}
+	s.startProgress(dep, spec.ProgressDeadlineSeconds)
+
+	if err := s.updateDeployment(dep); err != nil {
+		return nil, err
+	}
+
+	s.addEval(&proto.Evaluation{
+		Id:           uuid.UUID(),
+		Status:       proto.Evaluation_PENDING,
+		TriggeredBy:  proto.Evaluation_RESTART,
+		DeploymentID: dep.Id,
+		Type:         proto.EvaluationTypeCluster,
+	})
+	return dep, nil
+}
+
+// ReplaceInstance marks an instance to be replaced by a new one with the same name. If wipe
+// is set, the new instance does not reuse the volumes of the old one.
+func (s *Server) ReplaceInstance(id string, wipe bool) (*proto.Instance, error) {
+	instance, err := s.GetInstance(id)
+	if err != nil {
+		return nil, fmt.Errorf("instance '%s' not found: %v", id, err)
+	}
+	if instance.Status != proto.Instance_PENDING && instance.Status != proto.Instance_RUNNING {
+		return nil, fmt.Errorf("instance '%s' cannot be replaced with status %s", id, instance.Status)
+	}
+	if instance.DesiredStatus != proto.Instance_RUN {
+		return nil, fmt.Errorf("instance '%s' is already being stopped", id)
+	}
+
+	dep, err := s.LoadDeployment(instance.DeploymentID)
+	if err != nil {
+		return nil, err
+	}
+	if dep == nil {
+		return nil, fmt.Errorf("deployment does not exists '%s'", instance.DeploymentID)
+	}
+	if dep.Status != proto.DeploymentRunning && dep.Status != proto.DeploymentFailed {
+		dep = dep.Copy()
+		dep.Status = proto.DeploymentRunning
+
+		deadline, err := s.progressDeadline(dep)
+		if err != nil {
+			return nil, err
+		}
+		s.startProgress(dep, deadline)
+
+		if err := s.updateDeployment(dep); err != nil {
+			return nil, err
+		}
+	}
+
+	instance = instance.Copy()
+	instance.Replace = true
+	instance.Wipe = wipe
+	if err := s.UpsertInstance(instance); err != nil {
+		return nil, err
+	}
+
+	s.addEval(&proto.Evaluation{
+		Id:           uuid.UUID(),
+		Status:       proto.Evaluation_PENDING,
+		TriggeredBy:  proto.Evaluation_REPLACE,
+		DeploymentID: dep.Id,
+		Type:         proto.EvaluationTypeCluster,
+	})
+	return instance, nil
+}
+
 func (s *Server) GetComponentByID(deployment, compID string, sequence int64) (*proto.Component, error) {
 	return s.State.GetComponentByID2(deployment, compID, sequence)
 }
@@ -324,13 +810,27 @@ func (s *Server) taskQueue4() {
 
 		s.logger.Debug("handle eval", "type", eval.Type, "id", eval.Id, "cluster", eval.DeploymentID, "trigger", eval.TriggeredBy.String())
 
+		if s.isPaused(eval.DeploymentID) {
+			// a new evaluation is created on resume
+			s.logger.Debug("skip eval of paused deployment", "id", eval.Id, "cluster", eval.DeploymentID)
+			s.finishEval(eval, proto.Evaluation_CANCELLED, "deployment paused", nil, nil)
+			s.evalQueue.finalize(eval.Id)
+			continue
+		}
+
+		s.startEval(eval)
+
 		sched := s.newScheduler(eval.Type)
 		plan, err := sched.Process(eval)
 		if err != nil {
 			s.logger.Error("failed to process", "err", err)
+			s.finishEval(eval, proto.Evaluation_FAILED, "", nil, err)
 		} else {
 			if err := s.SubmitPlan(eval, plan); err != nil {
 				s.logger.Error("cannot submit plan", "err", err)
+				s.finishEval(eval, proto.Evaluation_FAILED, "", plan, err)
+			} else {
+				s.finishEval(eval, proto.Evaluation_COMPLETE, "", plan, nil)
 			}
 		}
 
@@ -340,23 +840,56 @@ func (s *Server) taskQueue4() {
 }
 
 func (s *Server) SubmitPlan(eval *proto.Evaluation, p *proto.Plan) error {
-	// update the state
-	for _, i := range p.NodeUpdate {
-		if err := s.UpsertInstance(i); err != nil {
-			return err
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// update the state with the part of the plan that is still valid
+	result, err := s.applyPlan(eval, p)
+	if err != nil {
+		return err
+	}
+	if result.refresh() {
+		// the deployment is not updated nor finalized with a partial
+		// plan, the new evaluation computes it again
+		p.Stale = result.stale
+		for _, i := range result.rejected {
+			p.Rejected = append(p.Rejected, i.ID)
 		}
+		s.reevaluate(eval, result)
+		return nil
 	}
 
+	var outputsChanged bool
 	if p.Deployment != nil {
 		dep := p.Deployment.Copy()
 		dep.Status = p.Status
+		dep.Reason = p.Reason
+		dep.Conditions = p.Conditions
+		dep.Blocked = p.Blocked
+
+		if p.Status == proto.DeploymentDone {
+			// the rollout converged before the deadline
+			s.progressTimers.cancel(dep.Id)
+
+			// publish the outputs once the deployment converges
+			var err error
+			if outputsChanged, err = s.publishOutputs(dep); err != nil {
+				s.logger.Error("failed to publish the outputs", "id", dep.Id, "err", err)
+			}
+		}
 
 		// update the state of the deployment if there is any change
-		if len(p.NodeUpdate) != 0 || p.Deployment.Status != p.Status {
+		if len(p.NodeUpdate) != 0 || p.Deployment.Status != p.Status || p.Deployment.Reason != p.Reason || blockedChanged(p.Deployment.Blocked, p.Blocked) || outputsChanged {
 			if err := s.State.UpdateDeployment(dep); err != nil {
 				return err
 			}
 		}
+		if outputsChanged {
+			s.logger.Info("Outputs published", "id", dep.Id)
+			if err := s.evalDependents(dep); err != nil {
+				return err
+			}
+		}
 	}
 
 	// if its done, finalize the component
@@ -390,6 +923,29 @@ func (s *Server) validateComponent(component *proto.Component) (*proto.Component
 			if grp.Count == 0 {
 				return nil, fmt.Errorf("count 0 for group %d", indx)
 			}
+			if grp.MinAvailable != 0 && grp.MaxUnavailable != 0 {
+				return nil, fmt.Errorf("minAvailable and maxUnavailable cannot be set together for group %d", indx)
+			}
+			if grp.MinAvailable < 0 || grp.MinAvailable > grp.Count {
+				return nil, fmt.Errorf("minAvailable for group %d must be between 0 and %d", indx, grp.Count)
+			}
+			if grp.MaxUnavailable < 0 || grp.MaxUnavailable > grp.Count {
+				return nil, fmt.Errorf("maxUnavailable for group %d must be between 0 and %d", indx, grp.Count)
+			}
+			if grp.ReplaceUnhealthySeconds < 0 {
+				return nil, fmt.Errorf("replaceUnhealthySeconds for group %d cannot be negative", indx)
+			}
+			if err := validateAutoscale(grp.Autoscale); err != nil {
+				return nil, fmt.Errorf("autoscale for group %d: %v", indx, err)
+			}
+		}
+		if obj.ProgressDeadlineSeconds < 0 {
+			return nil, fmt.Errorf("progressDeadlineSeconds cannot be negative")
+		}
+		if obj.PriorityClass != "" {
+			if _, ok := priorityClasses[obj.PriorityClass]; !ok {
+				return nil, fmt.Errorf("priority class '%s' not found", obj.PriorityClass)
+			}
 		}
 	case *proto.ResourceSpec:
 		// make sure the deployment exists
@@ -424,44 +980,143 @@ func (s *Server) validateComponent(component *proto.Component) (*proto.Component
 	if err != nil {
 		return nil, err
 	}
+
+	// the backend might have set the dependencies between the groups
+	if msg, err = proto.UnmarshalAny(component.Spec); err != nil {
+		return nil, err
+	}
+	if spec, ok := msg.(*proto.ClusterSpec); ok {
+		if err := validateGroupDependencies(spec); err != nil {
+			return nil, err
+		}
+		// the clusters referenced in the spec are dependencies too
+		if len(specReferences(spec)) != 0 {
+			if err := s.validateReferences(spec); err != nil {
+				return nil, err
+			}
+			component = component.Copy()
+			component.Spec = proto.MustMarshalAny(spec)
+		}
+	}
 	return component, nil
 }
 
 func (s *Server) UpsertInstance(n *proto.Instance) error {
-	s.logger.Debug("Upsert instance", "id", n.ID, "status", n.Status)
+	s.lock.Lock()
+	defer s.lock.Unlock()
 
+	return s.upsertInstanceLocked(n)
+}
+
+// UpdateInstance implements the ControlPlane interface
+func (s *Server) UpdateInstance(n *proto.Instance) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if err := s.State.UpsertNode(n); err != nil {
+	current, err := s.State.LoadNode(n.ID)
+	if err != nil {
 		return err
 	}
-	update := &InstanceUpdate{
-		InstanceID: n.ID,
+	if current.ModifyIndex != n.ModifyIndex {
+		return ErrInstanceModified
 	}
+	return s.upsertInstanceLocked(n)
+}
 
-	for _, ch := range s.subs {
-		select {
-		case ch <- update:
-		default:
-		}
+// upsertInstanceLocked writes the instance and notifies the subscribers.
+// The caller must hold the server lock.
+func (s *Server) upsertInstanceLocked(n *proto.Instance) error {
+	s.logger.Debug("Upsert instance", "id", n.ID, "status", n.Status)
+
+	// track the stop time to garbage collect the instance
+	stopped := n.Status == proto.Instance_STOPPED || n.Status == proto.Instance_OUT
+	if stopped && n.StoppedAt == nil {
+		n = n.Copy()
+		n.StoppedAt = ptypes.TimestampNow()
+	} else if !stopped && n.StoppedAt != nil {
+		n = n.Copy()
+		n.StoppedAt = nil
+	}
+	if err := s.State.UpsertNode(n); err != nil {
+		return err
 	}
+	s.events.publish(n.ID)
 	return nil
 }
 
+// updateDeployment writes the deployment while holding the server lock so
+// that it does not interleave with the application of a plan
+func (s *Server) updateDeployment(dep *proto.Deployment) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.State.UpdateDeployment(dep)
+}
+
 func (s *Server) GetInstance(instanceID string) (*proto.Instance, error) {
 	return s.State.LoadNode(instanceID)
 }
 
-func (s *Server) SubscribeInstanceUpdates() <-chan *InstanceUpdate {
-	s.lock.Lock()
-	defer s.lock.Unlock()
+// SubscribeInstanceUpdates implements the ControlPlane interface
+func (s *Server) SubscribeInstanceUpdates() *Subscription {
+	return s.events.subscribe()
+}
+
+// backendControlPlane returns the control plane of a backend. The backend
+// only receives the updates of the instances of its own deployments.
+func (s *Server) backendControlPlane(backend string) ControlPlane {
+	return &backendControlPlane{Server: s, backend: backend}
+}
+
+type backendControlPlane struct {
+	*Server
+
+	backend string
+}
 
-	if s.subs == nil {
-		s.subs = []chan *InstanceUpdate{}
+// SubscribeInstanceUpdates implements the ControlPlane interface
+func (b *backendControlPlane) SubscribeInstanceUpdates() *Subscription {
+	return b.events.subscribeFunc(b.ownsInstance)
+}
+
+// ownsInstance returns true if the instance belongs to a deployment of the backend
+func (b *backendControlPlane) ownsInstance(instanceID string) bool {
+	instance, err := b.GetInstance(instanceID)
+	if err != nil {
+		// the instance might have been removed, the update is passed
+		// so that the backend stops tracking it
+		return true
+	}
+	dep, err := b.LoadDeployment(instance.DeploymentID)
+	if err != nil {
+		b.logger.Error("failed to load the deployment of the instance", "id", instanceID, "err", err)
+		return false
 	}
-	ch := make(chan *InstanceUpdate, 10)
-	s.subs = append(s.subs, ch)
+	if dep == nil {
+		return false
+	}
+	return strings.EqualFold(dep.Backend, b.backend)
+}
 
-	return ch
+// instanceIDs returns the ids of the instances of all the deployments
+// for the subscribers that fall behind in the event bus
+func (s *Server) instanceIDs() ([]string, error) {
+	deps, err := s.State.ListDeployments()
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, d := range deps {
+		dep, err := s.State.LoadDeployment(d.Id)
+		if err != nil {
+			return nil, err
+		}
+		if dep == nil {
+			continue
+		}
+		for _, i := range dep.Instances {
+			ids = append(ids, i.ID)
+		}
+	}
+	return ids, nil
 }
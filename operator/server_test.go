@@ -0,0 +1,411 @@
+package operator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+	"github.com/teseraio/ensemble/operator/state/memdb"
+)
+
+func testServer(t *testing.T, handler Handler) *Server {
+	state, err := memdb.Factory(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		logger:    hclog.NewNullLogger(),
+		State:     state,
+		evalQueue: newEvalQueue(),
+		handlers: map[string]Handler{
+			"": handler,
+		},
+	}
+	s.events = newEventBus(defaultEventBufferSize, s.instanceIDs)
+	return s
+}
+
+// oddHandler only accepts groups with an odd number of instances
+type oddHandler struct {
+	nullHandler
+}
+
+func (o *oddHandler) Evaluate(comp *proto.Component) (*proto.Component, error) {
+	var spec proto.ClusterSpec
+	if err := gproto.Unmarshal(comp.Spec.Value, &spec); err != nil {
+		return nil, err
+	}
+	for _, grp := range spec.Groups {
+		if grp.Count%2 == 0 {
+			return nil, fmt.Errorf("odd number of nodes required")
+		}
+	}
+	return comp, nil
+}
+
+// mockHandler is a backend with a configurable specification
+type mockHandler struct {
+	*BaseOperator
+
+	name string
+	spec *Spec
+}
+
+func newMockHandler(spec *Spec) *mockHandler {
+	m := &mockHandler{spec: spec}
+	m.BaseOperator = &BaseOperator{}
+	m.BaseOperator.SetHandler(m)
+	return m
+}
+
+func (m *mockHandler) Name() string {
+	return m.name
+}
+
+func (m *mockHandler) Spec() *Spec {
+	return m.spec
+}
+
+func (m *mockHandler) Initialize(n []*proto.Instance, target *proto.Instance) (*proto.NodeSpec, error) {
+	return nil, nil
+}
+
+func (m *mockHandler) Client(node *proto.Instance) (interface{}, error) {
+	return nil, nil
+}
+
+func TestServer_Scale(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+
+	_, err := s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1},
+				{Type: "y", Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := s.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	comp, err := s.Scale(depID, "y", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(2))
+
+	var spec proto.ClusterSpec
+	assert.NoError(t, gproto.Unmarshal(comp.Spec.Value, &spec))
+	assert.Equal(t, spec.Groups[0].Count, int64(1))
+	assert.Equal(t, spec.Groups[1].Count, int64(3))
+
+	// the backend validation rules apply
+	_, err = s.Scale(depID, "y", 4)
+	assert.Error(t, err)
+
+	// the group has to exist
+	_, err = s.Scale(depID, "z", 3)
+	assert.Error(t, err)
+
+	// the group is required with multiple groups
+	_, err = s.Scale(depID, "", 3)
+	assert.Error(t, err)
+
+	// the deployment has to exist
+	_, err = s.Scale("unknown", "y", 3)
+	assert.Error(t, err)
+
+	// the only group of the deployment is used by default
+	_, err = s.State.Apply(&proto.Component{
+		Name: "b",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err = s.State.NameToDeployment("b")
+	assert.NoError(t, err)
+
+	comp, err = s.Scale(depID, "", 3)
+	assert.NoError(t, err)
+
+	assert.NoError(t, gproto.Unmarshal(comp.Spec.Value, &spec))
+	assert.Equal(t, spec.Groups[0].Count, int64(3))
+}
+
+func TestServer_Restore(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+
+	comp, err := s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	snap, err := s.State.Snapshot()
+	assert.NoError(t, err)
+
+	s2 := testServer(t, &oddHandler{})
+
+	// the version of the snapshot has to match
+	snap.Version = 0
+	assert.Error(t, s2.Restore(snap))
+
+	snap.Version = state.SnapshotVersion
+	assert.NoError(t, s2.Restore(snap))
+
+	depID, err := s2.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	comp2, err := s2.State.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, comp2.Id, comp.Id)
+}
+
+func TestServer_Compact(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+	s.config = &Config{
+		Retention: &state.RetentionPolicy{
+			InstanceTTL: time.Hour,
+		},
+	}
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	// the stop time is tracked once the instance is out
+	assert.NoError(t, s.UpsertInstance(&proto.Instance{
+		ID:           "i0",
+		DeploymentID: "dep1",
+		Status:       proto.Instance_OUT,
+	}))
+
+	i0, err := s.GetInstance("i0")
+	assert.NoError(t, err)
+	assert.NotNil(t, i0.StoppedAt)
+
+	assert.NoError(t, s.compact(time.Now()))
+	_, err = s.GetInstance("i0")
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.compact(time.Now().Add(2*time.Hour)))
+	_, err = s.GetInstance("i0")
+	assert.Error(t, err)
+}
+
+func TestServer_DependencyGraph(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+
+	for _, comp := range []*proto.Component{
+		{Name: "name1", Spec: proto.MustMarshalAny(&proto.ClusterSpec{})},
+		{Name: "name2", Spec: proto.MustMarshalAny(&proto.ClusterSpec{DependsOn: []string{"name1"}})},
+	} {
+		_, err := s.State.Apply(comp)
+		assert.NoError(t, err)
+	}
+
+	depID, err := s.State.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	graph, err := s.DependencyGraph()
+	assert.NoError(t, err)
+	assert.Len(t, graph.Nodes, 2)
+
+	for _, node := range graph.Nodes {
+		assert.False(t, node.Deleted)
+		if node.Name == "name2" {
+			assert.Equal(t, node.DependsOn, []string{depID})
+		} else {
+			assert.Empty(t, node.DependsOn)
+		}
+	}
+}
+
+func TestServer_UnhealthyReplaceTimers(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+
+	comp, err := s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 1, ReplaceUnhealthySeconds: 60},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := s.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	dep, err := s.LoadDeployment(depID)
+	assert.NoError(t, err)
+	dep.CompId = comp.Id
+	dep.Sequence = comp.Sequence
+	assert.NoError(t, s.State.UpdateDeployment(dep))
+
+	update := func(unhealthy bool, status proto.Instance_Status) {
+		i := &proto.Instance{
+			ID:           "i0",
+			DeploymentID: depID,
+			Group:        &proto.ClusterSpec_Group{Type: "x"},
+			Status:       status,
+		}
+		if unhealthy {
+			i.UnhealthySince = ptypes.TimestampNow()
+		}
+		assert.NoError(t, s.UpsertInstance(i))
+		assert.NoError(t, s.handleInstanceUpdate(&InstanceUpdate{InstanceID: "i0"}))
+	}
+
+	// the health of the instance flaps, there is only one timer
+	for i := 0; i < 5; i++ {
+		update(true, proto.Instance_RUNNING)
+		update(false, proto.Instance_RUNNING)
+	}
+	assert.False(t, s.unhealthyTimers.has("i0"))
+
+	update(true, proto.Instance_RUNNING)
+	assert.True(t, s.unhealthyTimers.has("i0"))
+	assert.Len(t, s.unhealthyTimers.timers, 1)
+
+	// the timer is cancelled once the instance stops
+	update(true, proto.Instance_STOPPED)
+	assert.False(t, s.unhealthyTimers.has("i0"))
+}
+
+func TestServer_RestoreUnhealthyTimers(t *testing.T) {
+	s := testServer(t, &oddHandler{})
+	defer s.unhealthyTimers.stop()
+
+	comp, err := s.State.Apply(&proto.Component{
+		Name: "a",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Type: "x", Count: 2, ReplaceUnhealthySeconds: 60},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := s.State.NameToDeployment("a")
+	assert.NoError(t, err)
+
+	dep, err := s.LoadDeployment(depID)
+	assert.NoError(t, err)
+	dep.CompId = comp.Id
+	dep.Sequence = comp.Sequence
+	assert.NoError(t, s.State.UpdateDeployment(dep))
+
+	// the instance i0 was already unhealthy before the operator started
+	for _, id := range []string{"i0", "i1"} {
+		i := &proto.Instance{
+			ID:           id,
+			DeploymentID: depID,
+			Group:        &proto.ClusterSpec_Group{Type: "x"},
+			Status:       proto.Instance_RUNNING,
+		}
+		if id == "i0" {
+			i.UnhealthySince = ptypes.TimestampNow()
+		}
+		assert.NoError(t, s.UpsertInstance(i))
+	}
+
+	assert.NoError(t, s.restoreUnhealthyTimers())
+	assert.True(t, s.unhealthyTimers.has("i0"))
+	assert.False(t, s.unhealthyTimers.has("i1"))
+}
+
+func TestServer_ProgressTimers(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:     "dep1",
+		Status: proto.DeploymentRunning,
+	}))
+	dep, err := s.LoadDeployment("dep1")
+	assert.NoError(t, err)
+
+	// a new rollout replaces the timer of the previous one
+	s.startProgress(dep, 60)
+	s.startProgress(dep, 60)
+	assert.Len(t, s.progressTimers.timers, 1)
+
+	// the timer is cancelled if the new spec does not have a deadline
+	s.startProgress(dep, 0)
+	assert.False(t, s.progressTimers.has("dep1"))
+
+	// or once the rollout converges
+	s.startProgress(dep, 60)
+	assert.NoError(t, s.SubmitPlan(&proto.Evaluation{DeploymentID: "dep1"}, &proto.Plan{
+		Deployment: dep,
+		Status:     proto.DeploymentDone,
+	}))
+	assert.False(t, s.progressTimers.has("dep1"))
+}
+
+func TestServer_RestoreProgressTimers(t *testing.T) {
+	s := testServer(t, &nullHandler{})
+	defer s.progressTimers.stop()
+
+	// addDeployment adds a deployment whose rollout started some time ago
+	addDeployment := func(name string, status string, elapsed time.Duration) string {
+		comp, err := s.State.Apply(&proto.Component{
+			Name: name,
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				ProgressDeadlineSeconds: 60,
+			}),
+		})
+		assert.NoError(t, err)
+
+		depID, err := s.State.NameToDeployment(name)
+		assert.NoError(t, err)
+
+		dep, err := s.LoadDeployment(depID)
+		assert.NoError(t, err)
+		dep.CompId = comp.Id
+		dep.Sequence = comp.Sequence
+		dep.Status = status
+		dep.ProgressStart, err = ptypes.TimestampProto(time.Now().Add(-elapsed))
+		assert.NoError(t, err)
+		assert.NoError(t, s.State.UpdateDeployment(dep))
+		return depID
+	}
+
+	running := addDeployment("a", proto.DeploymentRunning, time.Second)
+	expired := addDeployment("b", proto.DeploymentRunning, 2*time.Minute)
+	done := addDeployment("c", proto.DeploymentDone, 2*time.Minute)
+
+	assert.NoError(t, s.restoreProgressTimers())
+
+	// the rollout in progress keeps the time left to the deadline
+	assert.True(t, s.progressTimers.has(running))
+	assert.False(t, s.progressTimers.has(done))
+
+	// the expired deadline is evaluated right away
+	var evals []*proto.Evaluation
+	for i := 0; i < 100 && len(evals) == 0; i++ {
+		time.Sleep(5 * time.Millisecond)
+
+		var err error
+		evals, err = s.ListEvaluations(expired)
+		assert.NoError(t, err)
+	}
+	if assert.Len(t, evals, 1) {
+		assert.Equal(t, evals[0].TriggeredBy, proto.Evaluation_DEADLINE)
+	}
+}
@@ -2,9 +2,14 @@ package operator
 
 import (
 	"context"
+	"io"
 
+	gproto "github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type service struct {
@@ -20,7 +25,7 @@ func (s *service) Apply(ctx context.Context, component *proto.Component) (*proto
 	}
 	component, err = s.s.State.Apply(component)
 	if err != nil {
-		return nil, err
+		return nil, applyError(err)
 	}
 	if component == nil {
 		return &proto.Component{}, nil
@@ -28,6 +33,15 @@ func (s *service) Apply(ctx context.Context, component *proto.Component) (*proto
 	return component, nil
 }
 
+// applyError returns the conflicts with the Aborted code so that
+// the clients can retry with the latest version
+func applyError(err error) error {
+	if _, ok := err.(*state.ConflictError); ok {
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return err
+}
+
 func (s *service) ListDeployments(ctx context.Context, _ *empty.Empty) (*proto.ListDeploymentsResp, error) {
 	deps, err := s.s.State.ListDeployments()
 	if err != nil {
@@ -44,5 +58,107 @@ func (s *service) GetDeployment(ctx context.Context, req *proto.GetDeploymentReq
 	if err != nil {
 		return nil, err
 	}
+	if dep != nil {
+		dep = dep.Copy()
+		dep.BackendStatus = s.s.backendStatus(ctx, dep)
+	}
 	return dep, nil
 }
+
+func (s *service) Restart(ctx context.Context, req *proto.RestartReq) (*proto.Deployment, error) {
+	return s.s.Restart(req.Cluster, req.Group)
+}
+
+func (s *service) ReplaceInstance(ctx context.Context, req *proto.ReplaceInstanceReq) (*proto.Instance, error) {
+	return s.s.ReplaceInstance(req.Id, req.Wipe)
+}
+
+func (s *service) Pause(ctx context.Context, req *proto.PauseReq) (*proto.Deployment, error) {
+	return s.s.Pause(req.Cluster)
+}
+
+func (s *service) Resume(ctx context.Context, req *proto.ResumeReq) (*proto.Deployment, error) {
+	return s.s.Resume(req.Cluster)
+}
+
+func (s *service) Scale(ctx context.Context, req *proto.ScaleReq) (*proto.Component, error) {
+	component, err := s.s.Scale(req.Cluster, req.Group, req.Count)
+	if err != nil {
+		return nil, applyError(err)
+	}
+	if component == nil {
+		return &proto.Component{}, nil
+	}
+	return component, nil
+}
+
+func (s *service) Purge(ctx context.Context, req *proto.PurgeReq) (*empty.Empty, error) {
+	if err := s.s.Purge(req.Cluster); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *service) GetDependencyGraph(ctx context.Context, _ *empty.Empty) (*proto.DependencyGraph, error) {
+	return s.s.DependencyGraph()
+}
+
+func (s *service) ListEvaluations(ctx context.Context, req *proto.ListEvaluationsReq) (*proto.ListEvaluationsResp, error) {
+	evals, err := s.s.ListEvaluations(req.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &proto.ListEvaluationsResp{Evaluations: evals}, nil
+}
+
+func (s *service) GetEvaluation(ctx context.Context, req *proto.GetEvaluationReq) (*proto.Evaluation, error) {
+	return s.s.GetEvaluation(req.Id)
+}
+
+// snapshotChunkSize is the size of the chunks used to stream the snapshots
+const snapshotChunkSize = 512 * 1024
+
+func (s *service) SnapshotSave(_ *empty.Empty, stream proto.EnsembleService_SnapshotSaveServer) error {
+	snap, err := s.s.State.Snapshot()
+	if err != nil {
+		return err
+	}
+	data, err := gproto.Marshal(snap)
+	if err != nil {
+		return err
+	}
+	for len(data) != 0 {
+		size := snapshotChunkSize
+		if len(data) < size {
+			size = len(data)
+		}
+		if err := stream.Send(&proto.SnapshotChunk{Data: data[:size]}); err != nil {
+			return err
+		}
+		data = data[size:]
+	}
+	return nil
+}
+
+func (s *service) SnapshotRestore(stream proto.EnsembleService_SnapshotRestoreServer) error {
+	data := []byte{}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		data = append(data, chunk.Data...)
+	}
+
+	var snap proto.Snapshot
+	if err := gproto.Unmarshal(data, &snap); err != nil {
+		return err
+	}
+	if err := s.s.Restore(&snap); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&empty.Empty{})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,6 +15,7 @@ import (
 	"github.com/boltdb/bolt"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
 )
 
 var (
@@ -26,6 +28,9 @@ var (
 	metaKey = []byte("meta")
 	depKey  = []byte("meta")
 
+	// clusterKey is the id of the cluster component of the deployment
+	clusterKey = []byte("cluster")
+
 	// keys for the components table
 	nextAppliedKey = []byte("next")
 	lastSeqKey     = []byte("lastSeq")
@@ -49,19 +54,22 @@ func Factory(config map[string]interface{}) (*BoltDB, error) {
 	b := &BoltDB{
 		path:   path,
 		db:     db,
-		queue2: newTaskQueue(),
+		queue2: state.NewTaskQueue(),
 	}
 	if err := b.initialize(); err != nil {
+		db.Close()
 		return nil, err
 	}
 	return b, nil
 }
 
+var _ state.State = &BoltDB{}
+
 // BoltDB is a boltdb state implementation
 type BoltDB struct {
 	path       string
 	db         *bolt.DB
-	queue2     *taskQueue
+	queue2     *state.TaskQueue
 	waitChLock sync.Mutex
 	waitCh     map[string]chan struct{}
 }
@@ -80,10 +88,17 @@ func (b *BoltDB) Wait(id string) chan struct{} {
 }
 
 func (b *BoltDB) initialize() error {
+	// upgrade the layout before the buckets are created, otherwise
+	// an old database cannot be told apart from a new one
+	if err := b.migrate(); err != nil {
+		return err
+	}
+
 	buckets := [][]byte{
 		deploymentsBucket,
 		instancesBucket,
 		componentsBucket,
+		evaluationsBucket,
 	}
 	err := b.db.Update(func(tx *bolt.Tx) error {
 		for _, i := range buckets {
@@ -97,12 +112,26 @@ func (b *BoltDB) initialize() error {
 		return err
 	}
 
-	err = b.db.View(func(tx *bolt.Tx) error {
+	return b.queuePending()
+}
+
+// queuePending queues the next request of each deployment that is not paused
+func (b *BoltDB) queuePending() error {
+	err := b.db.View(func(tx *bolt.Tx) error {
 		bkt := tx.Bucket(deploymentsBucket)
 
 		return bkt.ForEach(func(k, v []byte) error {
 			clusterBkt := bkt.Bucket(k)
 
+			// the pending items of a paused deployment are queued on resume
+			paused, err := isPaused(clusterBkt)
+			if err != nil {
+				return err
+			}
+			if paused {
+				return nil
+			}
+
 			comps := clusterBkt.Bucket([]byte("components"))
 			if comps == nil {
 				return nil
@@ -127,6 +156,10 @@ func (b *BoltDB) initialize() error {
 				if err != nil {
 					return err
 				}
+				if comp.Status == proto.Component_BLOCKED {
+					// it is queued once the dependencies are applied
+					return nil
+				}
 				b.addTask(string(k), comp)
 			}
 			return nil
@@ -284,10 +317,13 @@ func (b *BoltDB) GetHistory(deploymentID string) ([]*proto.Component, error) {
 		return nil, fmt.Errorf("requests bucket not found")
 	}
 
+	seqs, err := seqKeys(reqs)
+	if err != nil {
+		return nil, err
+	}
 	result := []*proto.Component{}
-	c := reqs.Cursor()
-	for k, v := c.Seek([]byte("seq-")); k != nil; k, v = c.Next() {
-		component, err := b.getComponentFromBucket(string(v), comps)
+	for _, seq := range seqs {
+		component, err := b.getComponentFromBucket(string(reqs.Get(seqID(seq))), comps)
 		if err != nil {
 			return nil, err
 		}
@@ -317,19 +353,18 @@ func (b *BoltDB) GetComponentVersions(deploymentID string, id string) ([]*proto.
 		return nil, fmt.Errorf("bucket for component %s not found", id)
 	}
 
+	seqs, err := seqKeys(compBkt)
+	if err != nil {
+		return nil, err
+	}
 	result := []*proto.Component{}
-	err = compBkt.ForEach(func(k, v []byte) error {
+	for _, seq := range seqs {
 		component := proto.Component{}
-		if err := gproto.Unmarshal(v, &component); err != nil {
-			return err
+		if err := dbGet(compBkt, seqID(seq), &component); err != nil {
+			return nil, err
 		}
 		result = append(result, &component)
-		return nil
-	})
-	if err != nil {
-		return nil, err
 	}
-
 	return result, nil
 }
 
@@ -372,7 +407,7 @@ func (b *BoltDB) Finalize(deploymentID string) error {
 	}
 	defer tx.Rollback()
 
-	if _, ok := b.queue2.finalize(deploymentID); !ok {
+	if _, ok := b.queue2.Finalize(deploymentID); !ok {
 		return fmt.Errorf("task not found for deployment %s", deploymentID)
 	}
 
@@ -408,19 +443,42 @@ func (b *BoltDB) Finalize(deploymentID string) error {
 		return err
 	}
 
+	paused, err := isPaused(bkt)
+	if err != nil {
+		return err
+	}
+
 	// check the next one
 	data := reqs.Get(seqID(num + 1))
-	if data != nil {
-		// there is a new one, eval it
-		nextComp, err := b.updateComponentStatus(tx, deploymentID, string(data), proto.Component_QUEUED)
+	if data != nil && !paused {
+		// there is a new one, eval it unless it is the deletion of the
+		// cluster and there are clusters that depend on it
+		status := proto.Component_QUEUED
+
+		nextComp, err := b.getComponentFromBucket(string(data), compsBkt)
 		if err != nil {
 			return err
 		}
-		b.addTask(deploymentID, nextComp)
+		if nextComp.Action == proto.Component_DELETE && string(bkt.Get(clusterKey)) == nextComp.Id {
+			deleted, err := b.dependentsDeleted(tx, deploymentID)
+			if err != nil {
+				return err
+			}
+			if !deleted {
+				status = proto.Component_BLOCKED
+			}
+		}
+		if nextComp, err = b.updateComponentStatus(tx, deploymentID, string(data), status); err != nil {
+			return err
+		}
+		if status == proto.Component_QUEUED {
+			b.addTask(deploymentID, nextComp)
+		}
 	}
 
-	// check any dependency if we finished the first execution
-	if comp.Sequence == 1 {
+	// check any dependency if we finished the first execution or the
+	// deletion of the cluster (a new generation might be waiting)
+	if comp.Sequence == 1 || (comp.Action == proto.Component_DELETE && string(bkt.Get(clusterKey)) == comp.Id) {
 		dependsBkt := bkt.Bucket([]byte("depends"))
 		if dependsBkt == nil {
 			return fmt.Errorf("depends bucket not found")
@@ -437,7 +495,14 @@ func (b *BoltDB) Finalize(deploymentID string) error {
 			if err != nil {
 				return err
 			}
-			if blockedComp.Status == proto.Component_BLOCKED {
+			if blockedComp.Status != proto.Component_BLOCKED || blockedComp.Action == proto.Component_DELETE {
+				continue
+			}
+			ready, err := b.dependenciesReady(tx, blockedDeploymentID)
+			if err != nil {
+				return err
+			}
+			if ready {
 				nextComp, err := b.updateComponentStatus(tx, blockedDeploymentID, blockedComp.Id+"#"+strconv.Itoa(int(blockedComp.Sequence)), proto.Component_QUEUED)
 				if err != nil {
 					return err
@@ -447,6 +512,13 @@ func (b *BoltDB) Finalize(deploymentID string) error {
 		}
 	}
 
+	// the deletion of the clusters this one depends on might be waiting for it
+	if comp.Action == proto.Component_DELETE && string(bkt.Get(clusterKey)) == comp.Id {
+		if err := b.unblockDeletes(tx, bkt); err != nil {
+			return err
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
@@ -461,12 +533,69 @@ func (b *BoltDB) Finalize(deploymentID string) error {
 	return nil
 }
 
+// dependenciesReady returns true if all the clusters the deployment depends
+// on are applied. A previous generation of the cluster is ready once its
+// deletion is applied.
+func (b *BoltDB) dependenciesReady(tx *bolt.Tx, deploymentID string) (bool, error) {
+	depsBkt := tx.Bucket(deploymentsBucket)
+
+	depBkt := depsBkt.Bucket([]byte(deploymentID))
+	if depBkt == nil {
+		return false, nil
+	}
+	dependsBkt := depBkt.Bucket([]byte("depends"))
+	if dependsBkt == nil {
+		return true, nil
+	}
+
+	c := dependsBkt.Cursor()
+
+	prefix := []byte(dependsOnPrefix)
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		otherBkt := depsBkt.Bucket(bytes.TrimPrefix(k, prefix))
+		if otherBkt == nil {
+			// the dependency was purged
+			continue
+		}
+		clusterBkt := clusterBucket(otherBkt)
+		if clusterBkt == nil {
+			return false, nil
+		}
+		seqs, err := seqKeys(clusterBkt)
+		if err != nil {
+			return false, err
+		}
+
+		var applied bool
+		last := &proto.Component{}
+		for _, seq := range seqs {
+			if err := dbGet(clusterBkt, seqID(seq), last); err != nil {
+				return false, err
+			}
+			if last.Status == proto.Component_APPLIED {
+				applied = true
+			}
+		}
+		if !applied {
+			return false, nil
+		}
+		if last.Action == proto.Component_DELETE && last.Status != proto.Component_APPLIED {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func (b *BoltDB) addTask(deploymentID string, comp *proto.Component) {
-	b.queue2.add(&proto.Task{
+	b.queue2.Add(newTask(deploymentID, comp))
+}
+
+func newTask(deploymentID string, comp *proto.Component) *proto.Task {
+	return &proto.Task{
 		DeploymentID: deploymentID,
 		ComponentID:  comp.Id,
 		Sequence:     comp.Sequence,
-	})
+	}
 }
 
 func (b *BoltDB) applyComponent(bkt *bolt.Bucket, c *proto.Component) (*proto.Component, error) {
@@ -484,6 +613,10 @@ func (b *BoltDB) applyComponent(bkt *bolt.Bucket, c *proto.Component) (*proto.Co
 	if err != nil {
 		return nil, err
 	}
+	if err := state.CheckSequence(c, int64(nextSeq-1)); err != nil {
+		return nil, err
+	}
+	c.ExpectedSequence = 0
 
 	if nextSeq != 1 {
 		prev := &proto.Component{}
@@ -548,12 +681,15 @@ func addSequenceComponent2(bkt *bolt.Bucket) (int, bool, error) {
 }
 
 func (b *BoltDB) readLatestComponent(bkt *bolt.Bucket) (*proto.Component, error) {
-	c := bkt.Cursor()
+	seqs, err := seqKeys(bkt)
+	if err != nil {
+		return nil, err
+	}
 
 	var past *proto.Component
-	for k, v := c.Last(); k != nil; k, v = c.Prev() {
+	for i := len(seqs) - 1; i >= 0; i-- {
 		component := proto.Component{}
-		if err := gproto.Unmarshal(v, &component); err != nil {
+		if err := dbGet(bkt, seqID(seqs[i]), &component); err != nil {
 			return nil, err
 		}
 		if component.Status == proto.Component_APPLIED {
@@ -591,22 +727,53 @@ func (b *BoltDB) readDeploymentCluster(tx *bolt.Tx, id string) (*proto.Component
 		return nil, nil
 	}
 
-	compBkt := depBkt.Bucket([]byte("components"))
-	if compBkt == nil {
-		// components bucket does not exists
+	clusterBkt := clusterBucket(depBkt)
+	if clusterBkt == nil {
+		// the deployment does not have components
 		return nil, nil
 	}
+	comp, err := b.readLatestComponent(clusterBkt)
+	if err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
 
-	// since the components are included using ULID in lexicographic order,
-	// the first item in the components bucket will always be the cluster
-	c := compBkt.Cursor()
-	k, _ := c.First()
+// clusterBucket returns the bucket with the versions of the cluster component
+func clusterBucket(depBkt *bolt.Bucket) *bolt.Bucket {
+	compBkt := depBkt.Bucket([]byte("components"))
+	if compBkt == nil {
+		return nil
+	}
+	id := depBkt.Get(clusterKey)
+	if id == nil {
+		return nil
+	}
+	return compBkt.Bucket(id)
+}
 
-	clusterBkt := compBkt.Bucket(k)
-	comp, err := b.readLatestComponent(clusterBkt)
+// readLastCluster returns the last version of the cluster component of the
+// deployment, regardless of whether it is applied or not
+func (b *BoltDB) readLastCluster(tx *bolt.Tx, id string) (*proto.Component, error) {
+	depBkt := tx.Bucket(deploymentsBucket).Bucket([]byte(id))
+	if depBkt == nil {
+		return nil, nil
+	}
+	clusterBkt := clusterBucket(depBkt)
+	if clusterBkt == nil {
+		return nil, nil
+	}
+	seqs, err := seqKeys(clusterBkt)
 	if err != nil {
 		return nil, err
 	}
+	if len(seqs) == 0 {
+		return nil, nil
+	}
+	comp := &proto.Component{}
+	if err := dbGet(clusterBkt, seqID(seqs[len(seqs)-1]), comp); err != nil {
+		return nil, err
+	}
 	return comp, nil
 }
 
@@ -620,18 +787,30 @@ func (b *BoltDB) NameToDeployment(name string) (string, error) {
 	return b.nameToDeploymentID(tx, name)
 }
 
+// nameToDeploymentID resolves the deployment of a cluster by name. If the
+// cluster is re-created while the deletion of the previous generation is
+// in progress, the new generation is returned.
 func (b *BoltDB) nameToDeploymentID(tx *bolt.Tx, name string) (string, error) {
-	var deploymentID string
+	var deploymentID, deletingID string
 
 	err := tx.Bucket(deploymentsBucket).ForEach(func(k, v []byte) error {
 		comp, err := b.readDeploymentCluster(tx, string(k))
 		if err != nil {
 			return err
 		}
-		if comp.Action == proto.Component_DELETE {
+		if comp == nil || comp.Action == proto.Component_DELETE {
 			return nil
 		}
-		if comp.Name == name {
+		if comp.Name != name {
+			return nil
+		}
+		last, err := b.readLastCluster(tx, string(k))
+		if err != nil {
+			return err
+		}
+		if last.Action == proto.Component_DELETE {
+			deletingID = string(k)
+		} else {
 			deploymentID = string(k)
 		}
 		return nil
@@ -639,6 +818,9 @@ func (b *BoltDB) nameToDeploymentID(tx *bolt.Tx, name string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if deploymentID == "" {
+		return deletingID, nil
+	}
 	return deploymentID, nil
 }
 
@@ -681,6 +863,27 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 	}
 	defer tx.Rollback()
 
+	tasks := []*proto.Task{}
+	comp, err = b.applyTx(tx, comp, &tasks)
+	if err != nil {
+		return nil, err
+	}
+	if comp == nil {
+		return nil, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	for _, task := range tasks {
+		b.queue2.Add(task)
+	}
+	return comp, nil
+}
+
+// applyTx applies the component in the transaction and appends to tasks
+// the components that have to be queued once the transaction is committed
+func (b *BoltDB) applyTx(tx *bolt.Tx, comp *proto.Component, tasks *[]*proto.Task) (*proto.Component, error) {
 	msg, err := proto.UnmarshalAny(comp.Spec)
 	if err != nil {
 		return nil, err
@@ -702,6 +905,51 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	// a cluster created again while the deletion of the previous one is in
+	// progress starts a new generation, blocked until the deletion is applied
+	var prevGeneration string
+	if deploymentID != "" && isCluster && comp.Action == proto.Component_CREATE {
+		last, err := b.readLastCluster(tx, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil && last.Action == proto.Component_DELETE {
+			prevGeneration = deploymentID
+			deploymentID = ""
+		}
+	}
+
+	if isCluster && comp.Action == proto.Component_CREATE {
+		for _, name := range msg.(*proto.ClusterSpec).DependsOn {
+			if name == comp.Name {
+				return nil, fmt.Errorf("cluster %s cannot depend on itself", name)
+			}
+		}
+	}
+
+	// the clusters that depend on this one have to be deleted first
+	var waitDependents bool
+	if deploymentID != "" && isCluster && comp.Action == proto.Component_DELETE {
+		if err := b.deleteDependents(tx, deploymentID, comp, tasks); err != nil {
+			return nil, err
+		}
+		deleted, err := b.dependentsDeleted(tx, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		waitDependents = !deleted
+	}
+
+	if deploymentID != "" && isCluster && comp.Action == proto.Component_CREATE {
+		// the dependencies of an existing cluster are updated
+		ids, err := b.updateDependsOn(tx, deploymentID, comp.Name, msg.(*proto.ClusterSpec).DependsOn)
+		if err != nil {
+			return nil, err
+		}
+		dependsIDs = ids
+	}
+
 	if deploymentID == "" {
 		// only create a new deployment if its a cluster
 		if clusterRef.GetCluster() != "" {
@@ -733,6 +981,10 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 				}
 			}
 		}
+		if prevGeneration != "" {
+			dependsIDs = append(dependsIDs, prevGeneration)
+			dependsOn = append(dependsOn, prevGeneration)
+		}
 		deploymentID = uuid.UUID()
 	}
 
@@ -747,6 +999,12 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 		}
 	}
 
+	// new components are not queued while the deployment is paused
+	paused, err := isPaused(bkt)
+	if err != nil {
+		return nil, err
+	}
+
 	// write the dependencies now that the buckets are available
 	for _, id := range dependsIDs {
 		if err := b.writeDependsOn(tx, deploymentID, id); err != nil {
@@ -786,6 +1044,13 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 			return nil, err
 		}
 		resourceID = id
+
+		if isCluster {
+			depBkt := tx.Bucket(deploymentsBucket).Bucket([]byte(deploymentID))
+			if err := depBkt.Put(clusterKey, []byte(resourceID)); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	comp.Id = resourceID
@@ -797,8 +1062,8 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 		return nil, err
 	}
 
-	if addEval {
-		if len(dependsOn) == 0 {
+	if addEval && !paused {
+		if len(dependsOn) == 0 && !waitDependents {
 			comp.Status = proto.Component_QUEUED
 		} else {
 			comp.Status = proto.Component_BLOCKED
@@ -819,35 +1084,46 @@ func (b *BoltDB) Apply(comp *proto.Component) (*proto.Component, error) {
 	if err := reqs.Put(seqID2(int(nextSeqNum)), []byte(reqVal)); err != nil {
 		return nil, err
 	}
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
 
 	if comp.Status == proto.Component_QUEUED {
-		b.addTask(deploymentID, comp)
+		*tasks = append(*tasks, newTask(deploymentID, comp))
 	}
 	return comp, nil
 }
 
 func getLatestSequence(bkt *bolt.Bucket) (int, error) {
-	nextSeq := int(1)
+	seqs, err := seqKeys(bkt)
+	if err != nil {
+		return 0, err
+	}
+	if len(seqs) == 0 {
+		return 1, nil
+	}
+	return int(seqs[len(seqs)-1]) + 1, nil
+}
+
+// seqKeys returns the numbers of the seq-<n> keys in the bucket in numeric
+// order. Boltdb sorts the keys lexicographically (i.e. seq-10 before seq-2).
+func seqKeys(bkt *bolt.Bucket) ([]int64, error) {
+	prefix := []byte("seq-")
+
+	seqs := []int64{}
 	c := bkt.Cursor()
-	if k, _ := c.Last(); k != nil {
-		var err error
-		if nextSeq, err = strconv.Atoi(strings.TrimPrefix(string(k), "seq-")); err != nil {
-			return 0, err
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+		num, err := strconv.Atoi(string(bytes.TrimPrefix(k, prefix)))
+		if err != nil {
+			return nil, err
 		}
-		nextSeq++
+		seqs = append(seqs, int64(num))
 	}
-	return nextSeq, nil
+	sort.Slice(seqs, func(i, j int) bool {
+		return seqs[i] < seqs[j]
+	})
+	return seqs, nil
 }
 
 func (b *BoltDB) GetTask(ctx context.Context) *proto.Task {
-	task := b.queue2.pop(ctx)
-	if task == nil {
-		return nil
-	}
-	return task.Task
+	return b.queue2.Pop(ctx)
 }
 
 func (b *BoltDB) ListDeployments() ([]*proto.Deployment, error) {
@@ -955,6 +1231,17 @@ func (b *BoltDB) UpdateDeployment(d *proto.Deployment) error {
 	dd := d.Copy()
 	dd.Instances = nil
 
+	// the paused flag is only modified with SetPaused
+	prev := &proto.Deployment{}
+	if err := dbGet(depBkt, depKey, prev); err == nil {
+		dd.Paused = prev.Paused
+		dd.ModifyIndex = prev.ModifyIndex + 1
+	} else if err == errNotFound {
+		dd.ModifyIndex = 1
+	} else {
+		return err
+	}
+
 	if err := dbPut(depBkt, depKey, dd); err != nil {
 		return err
 	}
@@ -964,6 +1251,110 @@ func (b *BoltDB) UpdateDeployment(d *proto.Deployment) error {
 	return nil
 }
 
+// SetPaused pauses or resumes a deployment. While the deployment is paused, the new
+// components are not queued. On resume, the next pending component is queued.
+func (b *BoltDB) SetPaused(id string, paused bool) (*proto.Deployment, error) {
+	tx, err := b.db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	depBkt := tx.Bucket(deploymentsBucket).Bucket([]byte(id))
+	if depBkt == nil {
+		return nil, fmt.Errorf("deployment does not exists '%s'", id)
+	}
+
+	dep := &proto.Deployment{}
+	if err := dbGet(depBkt, depKey, dep); err != nil {
+		if err != errNotFound {
+			return nil, err
+		}
+		comp, err := b.readDeploymentCluster(tx, id)
+		if err != nil {
+			return nil, err
+		}
+		dep.Id = id
+		if comp != nil {
+			dep.Name = comp.Name
+		}
+	}
+	dep.Paused = paused
+	dep.ModifyIndex++
+	if err := dbPut(depBkt, depKey, dep); err != nil {
+		return nil, err
+	}
+
+	var next *proto.Component
+	if !paused && !b.queue2.Exists(id) {
+		reqs := depBkt.Bucket([]byte("requests"))
+		if reqs == nil {
+			return nil, fmt.Errorf("requests bucket not found")
+		}
+		num, err := getSeqNumber(reqs, nextAppliedKey)
+		if err != nil {
+			return nil, err
+		}
+		if data := reqs.Get(seqID(num)); data != nil {
+			comp, err := b.getComponentFromBucket(string(data), depBkt.Bucket([]byte("components")))
+			if err != nil {
+				return nil, err
+			}
+			if comp.Status == proto.Component_PENDING || comp.Status == proto.Component_QUEUED {
+				if next, err = b.updateComponentStatus(tx, id, string(data), proto.Component_QUEUED); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	if next != nil {
+		b.addTask(id, next)
+	}
+	return dep, nil
+}
+
+// Purge implements the State interface
+func (b *BoltDB) Purge(id string) error {
+	tx, err := b.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	comp, err := b.readDeploymentCluster(tx, id)
+	if err != nil {
+		return err
+	}
+	if comp == nil {
+		return fmt.Errorf("deployment does not exists '%s'", id)
+	}
+	if comp.Action != proto.Component_DELETE || comp.Status != proto.Component_APPLIED {
+		return fmt.Errorf("deployment '%s' is not deleted", id)
+	}
+	if b.queue2.Exists(id) {
+		return fmt.Errorf("deployment '%s' has a task in progress", id)
+	}
+	if _, err := removeDeployment(tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func isPaused(depBkt *bolt.Bucket) (bool, error) {
+	dep := &proto.Deployment{}
+	if err := dbGet(depBkt, depKey, dep); err != nil {
+		if err == errNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return dep.Paused, nil
+}
+
 func (b *BoltDB) LoadNode(id string) (*proto.Instance, error) {
 	tx, err := b.db.Begin(false)
 	if err != nil {
@@ -998,7 +1389,17 @@ func (b *BoltDB) UpsertNode(n *proto.Instance) error {
 	if err := depBkt.Put([]byte("node-"+n.ID), nil); err != nil {
 		return err
 	}
-	if err := dbPut(instancesBkt, []byte(n.ID), n); err != nil {
+
+	nn := n.Copy()
+	prev := &proto.Instance{}
+	if err := dbGet(instancesBkt, []byte(n.ID), prev); err == nil {
+		nn.ModifyIndex = prev.ModifyIndex + 1
+	} else if err == errNotFound {
+		nn.ModifyIndex = 1
+	} else {
+		return err
+	}
+	if err := dbPut(instancesBkt, []byte(n.ID), nn); err != nil {
 		return err
 	}
 	if err := tx.Commit(); err != nil {
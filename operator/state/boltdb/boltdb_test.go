@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
 )
 
 func testBoltdb(t *testing.T, pathRaw ...string) *BoltDB {
@@ -24,6 +25,12 @@ func testBoltdb(t *testing.T, pathRaw ...string) *BoltDB {
 	return st
 }
 
+func TestSuite(t *testing.T) {
+	state.TestSuite(t, func(t *testing.T) state.State {
+		return testBoltdb(t)
+	})
+}
+
 func TestListDeployments(t *testing.T) {
 	db := testBoltdb(t)
 
@@ -119,15 +126,15 @@ func TestApplySecond_FinalizeFirst(t *testing.T) {
 	assert.NoError(t, err)
 
 	// you can only pop one value
-	task1 := db.queue2.popImpl()
+	task1 := db.queue2.TryPop()
 	assert.NotNil(t, task1)
 	assert.Equal(t, task1.ComponentID, comp0.Id)
-	assert.Nil(t, db.queue2.popImpl())
+	assert.Nil(t, db.queue2.TryPop())
 
 	assert.NoError(t, db.Finalize(depID))
 
 	// pop the second evaluation
-	task2 := db.queue2.popImpl()
+	task2 := db.queue2.TryPop()
 	assert.NotNil(t, task2)
 	assert.Equal(t, task2.ComponentID, comp0.Id)
 	assert.Equal(t, task2.Sequence, int64(2))
@@ -149,7 +156,7 @@ func TestApplySecond_FirstQueued(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, comp0.Sequence, int64(1))
 
-	task1 := db.queue2.popImpl()
+	task1 := db.queue2.TryPop()
 	assert.Equal(t, task1.ComponentID, comp0.Id)
 
 	comp1, err := db.Apply(&proto.Component{
@@ -179,7 +186,7 @@ func TestApplySecond_FirstAlreadyDone(t *testing.T) {
 	depID, err := db.NameToDeployment("name1")
 	assert.NoError(t, err)
 
-	task0 := db.queue2.popImpl()
+	task0 := db.queue2.TryPop()
 	assert.Equal(t, task0.ComponentID, comp0.Id)
 	assert.Equal(t, depID, task0.DeploymentID)
 
@@ -244,11 +251,13 @@ func TestApplyDelete_ClusterReuseName(t *testing.T) {
 
 	// create again with the same name, the new component should start
 	// with sequence 1 again
-	_, err = db.Apply(&proto.Component{
+	compB1, err := db.Apply(&proto.Component{
 		Name: "name1",
 		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
 	})
-	assert.Error(t, err)
+	assert.NoError(t, err)
+	assert.Equal(t, compB1.Sequence, int64(1))
+	assert.NotEqual(t, compB1.Id, compA1.Id)
 }
 
 func TestApplyDelete_ResourceReuseName(t *testing.T) {
@@ -409,15 +418,15 @@ func TestDependsOn_PendingComponent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, comp2.Status, proto.Component_BLOCKED)
 
-	task1 := db.queue2.popImpl()
+	task1 := db.queue2.TryPop()
 	assert.NotNil(t, task1)
 	assert.Equal(t, task1.ComponentID, comp1.Id)
-	assert.Nil(t, db.queue2.popImpl())
+	assert.Nil(t, db.queue2.TryPop())
 
 	// it should trigger 'name2' component
 	assert.NoError(t, db.Finalize(task1.DeploymentID))
 
-	task2 := db.queue2.popImpl()
+	task2 := db.queue2.TryPop()
 	assert.NotNil(t, task2)
 	assert.Equal(t, task2.ComponentID, comp2.Id)
 
@@ -446,7 +455,7 @@ func TestDependsOn_CompletedComponent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, comp1.Status, proto.Component_QUEUED)
 
-	task1 := db.queue2.popImpl()
+	task1 := db.queue2.TryPop()
 	assert.NotNil(t, task1)
 	assert.NoError(t, db.Finalize(task1.DeploymentID))
 
@@ -461,7 +470,7 @@ func TestDependsOn_CompletedComponent(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, comp2.Status, proto.Component_QUEUED)
 
-	task2 := db.queue2.popImpl()
+	task2 := db.queue2.TryPop()
 	assert.NotNil(t, task2)
 }
 
@@ -502,3 +511,65 @@ func TestDeployment_UpsertInstance(t *testing.T) {
 	assert.Len(t, depRes.Instances, 1)
 	assert.Equal(t, depRes.Instances[0].ID, "i0")
 }
+
+func TestPause_ApplyPending(t *testing.T) {
+	// components applied while paused are not queued until resume
+	path := "/tmp/db-" + uuid.UUID()
+	db := testBoltdb(t, path)
+
+	comp0, err := db.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := db.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	assert.NotNil(t, db.queue2.TryPop())
+	assert.NoError(t, db.Finalize(depID))
+
+	dep, err := db.SetPaused(depID, true)
+	assert.NoError(t, err)
+	assert.True(t, dep.Paused)
+	assert.Equal(t, dep.Name, "name1")
+
+	comp1, err := db.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp1.Status, proto.Component_PENDING)
+	assert.Nil(t, db.queue2.TryPop())
+
+	// the flag is not modified by the deployment updates
+	dep, err = db.LoadDeployment(depID)
+	assert.NoError(t, err)
+	dep.Paused = false
+	assert.NoError(t, db.UpdateDeployment(dep))
+
+	dep, err = db.LoadDeployment(depID)
+	assert.NoError(t, err)
+	assert.True(t, dep.Paused)
+
+	// the pending component is not queued after a restart
+	assert.NoError(t, db.Close())
+	db = testBoltdb(t, path)
+	assert.Nil(t, db.queue2.TryPop())
+
+	_, err = db.SetPaused(depID, false)
+	assert.NoError(t, err)
+
+	task := db.queue2.TryPop()
+	assert.NotNil(t, task)
+	assert.Equal(t, task.ComponentID, comp0.Id)
+	assert.Equal(t, task.Sequence, int64(2))
+
+	vers, err := db.GetComponentVersions(depID, comp0.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, vers[1].Status, proto.Component_QUEUED)
+}
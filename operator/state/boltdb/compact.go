@@ -0,0 +1,308 @@
+package boltdb
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// Compact implements the State interface
+func (b *BoltDB) Compact(policy *state.RetentionPolicy, now time.Time) (*state.CompactStats, error) {
+	tx, err := b.db.Begin(true)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	stats := &state.CompactStats{}
+	depsBkt := tx.Bucket(deploymentsBucket)
+
+	// the buckets cannot be modified while iterating over them
+	ids := []string{}
+	if err := depsBkt.ForEach(func(k, v []byte) error {
+		ids = append(ids, string(k))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		purged, err := b.purgeDeployment(tx, id, policy, now, stats)
+		if err != nil {
+			return nil, err
+		}
+		if purged {
+			continue
+		}
+		if err := compactDeployment(depsBkt.Bucket([]byte(id)), policy, now, stats); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := compactInstances(tx, policy, now, stats); err != nil {
+		return nil, err
+	}
+	if err := compactEvaluations(tx, policy, now, stats); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// purgeDeployment removes the deployment if the deletion of the cluster is expired
+func (b *BoltDB) purgeDeployment(tx *bolt.Tx, id string, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) (bool, error) {
+	comp, err := b.readDeploymentCluster(tx, id)
+	if err != nil {
+		return false, err
+	}
+	if comp == nil || !policy.DeleteExpired(comp, now) {
+		return false, nil
+	}
+	if b.queue2.Exists(id) {
+		// there is a task in progress for the deployment
+		return false, nil
+	}
+
+	instances, err := removeDeployment(tx, id)
+	if err != nil {
+		return false, err
+	}
+	stats.Deployments++
+	stats.Instances += instances
+	return true, nil
+}
+
+// removeDeployment removes the deployment, its instances and the references
+// to it in other deployments. It returns the number of instances removed.
+func removeDeployment(tx *bolt.Tx, id string) (int, error) {
+	depsBkt := tx.Bucket(deploymentsBucket)
+	instancesBkt := tx.Bucket(instancesBucket)
+	depBkt := depsBkt.Bucket([]byte(id))
+
+	nodes := [][]byte{}
+	if err := depBkt.ForEach(func(k, v []byte) error {
+		if bytes.HasPrefix(k, []byte(nodePrefix)) {
+			nodes = append(nodes, append([]byte{}, bytes.TrimPrefix(k, []byte(nodePrefix))...))
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	for _, node := range nodes {
+		if err := instancesBkt.Delete(node); err != nil {
+			return 0, err
+		}
+	}
+
+	// remove the references to this deployment in the other deployments
+	if dependsBkt := depBkt.Bucket([]byte("depends")); dependsBkt != nil {
+		refs := map[string]string{}
+		if err := dependsBkt.ForEach(func(k, v []byte) error {
+			if bytes.HasPrefix(k, []byte(dependsOnPrefix)) {
+				refs[string(bytes.TrimPrefix(k, []byte(dependsOnPrefix)))] = dependencyOfPrefix + id
+			} else if bytes.HasPrefix(k, []byte(dependencyOfPrefix)) {
+				refs[string(bytes.TrimPrefix(k, []byte(dependencyOfPrefix)))] = dependsOnPrefix + id
+			}
+			return nil
+		}); err != nil {
+			return 0, err
+		}
+		for other, key := range refs {
+			otherBkt := depsBkt.Bucket([]byte(other))
+			if otherBkt == nil {
+				continue
+			}
+			if otherDepends := otherBkt.Bucket([]byte("depends")); otherDepends != nil {
+				if err := otherDepends.Delete([]byte(key)); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+
+	if _, err := removeEvaluations(tx, func(eval *proto.Evaluation) bool {
+		return eval.DeploymentID == id
+	}); err != nil {
+		return 0, err
+	}
+
+	if err := depsBkt.DeleteBucket([]byte(id)); err != nil {
+		return 0, err
+	}
+	return len(nodes), nil
+}
+
+// compactDeployment removes the old versions of the components of the
+// deployment and the requests that reference them
+func compactDeployment(depBkt *bolt.Bucket, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) error {
+	compsBkt := depBkt.Bucket([]byte("components"))
+	if compsBkt == nil {
+		return nil
+	}
+	reqs := depBkt.Bucket([]byte("requests"))
+	if reqs == nil {
+		return nil
+	}
+
+	dep := &proto.Deployment{}
+	if err := dbGet(depBkt, depKey, dep); err != nil && err != errNotFound {
+		return err
+	}
+
+	compIDs := [][]byte{}
+	if err := compsBkt.ForEach(func(k, v []byte) error {
+		compIDs = append(compIDs, append([]byte{}, k...))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	clusterID := depBkt.Get(clusterKey)
+
+	removed := map[string]struct{}{}
+	for _, compID := range compIDs {
+		compBkt := compsBkt.Bucket(compID)
+
+		seqs, err := seqKeys(compBkt)
+		if err != nil {
+			return err
+		}
+		versions := []*proto.Component{}
+		for _, seq := range seqs {
+			comp := &proto.Component{}
+			if err := dbGet(compBkt, seqID(seq), comp); err != nil {
+				return err
+			}
+			versions = append(versions, comp)
+		}
+		if len(versions) == 0 {
+			continue
+		}
+
+		// the cluster is only removed with the whole deployment
+		if !bytes.Equal(compID, clusterID) && policy.DeleteExpired(versions[len(versions)-1], now) {
+			for _, seq := range seqs {
+				removed[refKey(string(compID), seq)] = struct{}{}
+			}
+			if err := compsBkt.DeleteBucket(compID); err != nil {
+				return err
+			}
+			stats.Components += len(seqs)
+			continue
+		}
+
+		var current int64
+		if dep.CompId == string(compID) {
+			current = dep.Sequence
+		}
+		for _, seq := range policy.RemovableVersions(versions, current) {
+			if err := compBkt.Delete(seqID(seq)); err != nil {
+				return err
+			}
+			removed[refKey(string(compID), seq)] = struct{}{}
+			stats.Components++
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+	return compactRequests(reqs, removed, stats)
+}
+
+// compactRequests removes the requests of the removed versions and numbers
+// the remaining ones from one again
+func compactRequests(reqs *bolt.Bucket, removed map[string]struct{}, stats *state.CompactStats) error {
+	next, err := getSeqNumber(reqs, nextAppliedKey)
+	if err != nil {
+		return err
+	}
+	seqs, err := seqKeys(reqs)
+	if err != nil {
+		return err
+	}
+
+	refs := [][]byte{}
+	var newNext int64
+	for _, seq := range seqs {
+		if seq == next {
+			// the next request is never applied yet and it is not removed
+			newNext = int64(len(refs) + 1)
+		}
+		ref := reqs.Get(seqID(seq))
+		if _, ok := removed[string(ref)]; ok {
+			stats.Requests++
+			continue
+		}
+		// copy since the value is only valid during the transaction
+		refs = append(refs, append([]byte{}, ref...))
+	}
+	if newNext == 0 {
+		// all the requests are applied
+		newNext = int64(len(refs) + 1)
+	}
+
+	for _, seq := range seqs {
+		if err := reqs.Delete(seqID(seq)); err != nil {
+			return err
+		}
+	}
+	for indx, ref := range refs {
+		if err := reqs.Put(seqID2(indx+1), ref); err != nil {
+			return err
+		}
+	}
+	if next != 0 {
+		if err := putSeqNumber(reqs, nextAppliedKey, newNext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compactInstances removes the instances that are out for longer than the ttl
+func compactInstances(tx *bolt.Tx, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) error {
+	if policy.InstanceTTL == 0 {
+		return nil
+	}
+
+	instancesBkt := tx.Bucket(instancesBucket)
+	depsBkt := tx.Bucket(deploymentsBucket)
+
+	expired := []*proto.Instance{}
+	if err := instancesBkt.ForEach(func(k, v []byte) error {
+		instance := &proto.Instance{}
+		if err := dbGet(instancesBkt, k, instance); err != nil {
+			return err
+		}
+		if policy.InstanceExpired(instance, now) {
+			expired = append(expired, instance)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, instance := range expired {
+		if err := instancesBkt.Delete([]byte(instance.ID)); err != nil {
+			return err
+		}
+		if depBkt := depsBkt.Bucket([]byte(instance.DeploymentID)); depBkt != nil {
+			if err := depBkt.Delete([]byte(nodePrefix + instance.ID)); err != nil {
+				return err
+			}
+		}
+		stats.Instances++
+	}
+	return nil
+}
+
+func refKey(compID string, seq int64) string {
+	return compID + "#" + strconv.Itoa(int(seq))
+}
@@ -0,0 +1,257 @@
+package boltdb
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/boltdb/bolt"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// GetDependencies implements the State interface
+func (b *BoltDB) GetDependencies(id string) ([]string, []string, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	depBkt := tx.Bucket(deploymentsBucket).Bucket([]byte(id))
+	if depBkt == nil {
+		return nil, nil, fmt.Errorf("deployment does not exists '%s'", id)
+	}
+	dependsBkt := depBkt.Bucket([]byte("depends"))
+	if dependsBkt == nil {
+		return []string{}, []string{}, nil
+	}
+	return listDepends(dependsBkt, dependsOnPrefix), listDepends(dependsBkt, dependencyOfPrefix), nil
+}
+
+// listDepends returns the ids of the deployments referenced with the prefix
+func listDepends(dependsBkt *bolt.Bucket, prefix string) []string {
+	ids := []string{}
+
+	c := dependsBkt.Cursor()
+	for k, _ := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = c.Next() {
+		ids = append(ids, strings.TrimPrefix(string(k), prefix))
+	}
+	return ids
+}
+
+func dependsBucket(tx *bolt.Tx, id string) *bolt.Bucket {
+	depBkt := tx.Bucket(deploymentsBucket).Bucket([]byte(id))
+	if depBkt == nil {
+		return nil
+	}
+	return depBkt.Bucket([]byte("depends"))
+}
+
+// liveDependents returns the deployments that depend on this one and
+// are not deleted or being deleted
+func (b *BoltDB) liveDependents(tx *bolt.Tx, id string) ([]*proto.Component, error) {
+	dependsBkt := dependsBucket(tx, id)
+	if dependsBkt == nil {
+		return nil, nil
+	}
+
+	res := []*proto.Component{}
+	for _, other := range listDepends(dependsBkt, dependencyOfPrefix) {
+		last, err := b.readLastCluster(tx, other)
+		if err != nil {
+			return nil, err
+		}
+		if last != nil && last.Action != proto.Component_DELETE {
+			res = append(res, last)
+		}
+	}
+	return res, nil
+}
+
+// dependentsDeleted returns true if the deletion of all the deployments
+// that depend on this one is applied
+func (b *BoltDB) dependentsDeleted(tx *bolt.Tx, id string) (bool, error) {
+	dependsBkt := dependsBucket(tx, id)
+	if dependsBkt == nil {
+		return true, nil
+	}
+	cluster, err := b.readLastCluster(tx, id)
+	if err != nil {
+		return false, err
+	}
+	for _, other := range listDepends(dependsBkt, dependencyOfPrefix) {
+		last, err := b.readLastCluster(tx, other)
+		if err != nil {
+			return false, err
+		}
+		if last == nil {
+			// the dependent was purged
+			continue
+		}
+		if last.Action == proto.Component_DELETE && last.Status == proto.Component_APPLIED {
+			continue
+		}
+		if cluster != nil && cluster.Name == last.Name {
+			// a new generation of the cluster waits for this one instead
+			continue
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// deleteDependents deletes the clusters that depend on the deployment if the
+// deletion is a cascade one. Otherwise, it fails if there is any of them.
+func (b *BoltDB) deleteDependents(tx *bolt.Tx, id string, comp *proto.Component, tasks *[]*proto.Task) error {
+	dependents, err := b.liveDependents(tx, id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) == 0 {
+		return nil
+	}
+	if !comp.Cascade {
+		names := []string{}
+		for _, dependent := range dependents {
+			names = append(names, dependent.Name)
+		}
+		return fmt.Errorf("cluster %s is a dependency of %s, use a cascade delete to remove them too", comp.Name, strings.Join(names, ", "))
+	}
+	for _, dependent := range dependents {
+		del := &proto.Component{
+			Name:     dependent.Name,
+			Spec:     dependent.Spec,
+			Metadata: dependent.Metadata,
+			Action:   proto.Component_DELETE,
+			Cascade:  true,
+		}
+		if _, err := b.applyTx(tx, del, tasks); err != nil {
+			return fmt.Errorf("failed to delete the dependent cluster %s: %v", dependent.Name, err)
+		}
+	}
+	return nil
+}
+
+// unblockDeletes queues the deletion of the clusters the deployment depends on
+// that were waiting for the deletion of their dependents
+func (b *BoltDB) unblockDeletes(tx *bolt.Tx, depBkt *bolt.Bucket) error {
+	dependsBkt := depBkt.Bucket([]byte("depends"))
+	if dependsBkt == nil {
+		return nil
+	}
+	for _, id := range listDepends(dependsBkt, dependsOnPrefix) {
+		comp, err := b.readDeploymentCluster(tx, id)
+		if err != nil {
+			return err
+		}
+		if comp == nil || comp.Status != proto.Component_BLOCKED || comp.Action != proto.Component_DELETE {
+			continue
+		}
+		deleted, err := b.dependentsDeleted(tx, id)
+		if err != nil {
+			return err
+		}
+		if !deleted {
+			continue
+		}
+		nextComp, err := b.updateComponentStatus(tx, id, comp.Id+"#"+strconv.Itoa(int(comp.Sequence)), proto.Component_QUEUED)
+		if err != nil {
+			return err
+		}
+		b.addTask(id, nextComp)
+	}
+	return nil
+}
+
+// updateDependsOn resolves the dependencies of an existing cluster. It fails
+// if any of them would create a cycle. The references to the clusters that
+// are not a dependency anymore are removed.
+func (b *BoltDB) updateDependsOn(tx *bolt.Tx, id string, name string, dependsOn []string) ([]string, error) {
+	ids := []string{}
+	for _, dependency := range dependsOn {
+		other, err := b.nameToDeploymentID(tx, dependency)
+		if err != nil {
+			return nil, err
+		}
+		if other == "" {
+			return nil, fmt.Errorf("dependency component %s not found", dependency)
+		}
+		if path := b.dependsPath(tx, other, id); path != nil {
+			names := []string{name}
+			for _, elem := range path {
+				comp, err := b.readLastCluster(tx, elem)
+				if err != nil {
+					return nil, err
+				}
+				names = append(names, comp.Name)
+			}
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(names, " -> "))
+		}
+		ids = append(ids, other)
+	}
+
+	dependsBkt := dependsBucket(tx, id)
+	if dependsBkt == nil {
+		return ids, nil
+	}
+
+	// remove the dependencies that are not used anymore
+	for _, other := range listDepends(dependsBkt, dependsOnPrefix) {
+		found := false
+		for _, i := range ids {
+			if i == other {
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		comp, err := b.readLastCluster(tx, other)
+		if err != nil {
+			return nil, err
+		}
+		if comp != nil && comp.Name == name {
+			// keep the reference to the previous generation of the cluster
+			continue
+		}
+		if err := dependsBkt.Delete([]byte(dependsOnPrefix + other)); err != nil {
+			return nil, err
+		}
+		if otherBkt := dependsBucket(tx, other); otherBkt != nil {
+			if err := otherBkt.Delete([]byte(dependencyOfPrefix + id)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ids, nil
+}
+
+// dependsPath returns the deployments in the path of dependencies
+// from one deployment to another (if any)
+func (b *BoltDB) dependsPath(tx *bolt.Tx, from, to string) []string {
+	visited := map[string]struct{}{}
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if _, ok := visited[id]; ok {
+			return nil
+		}
+		visited[id] = struct{}{}
+
+		dependsBkt := dependsBucket(tx, id)
+		if dependsBkt == nil {
+			return nil
+		}
+		for _, other := range listDepends(dependsBkt, dependsOnPrefix) {
+			if path := visit(other); path != nil {
+				return append([]string{id}, path...)
+			}
+		}
+		return nil
+	}
+	return visit(from)
+}
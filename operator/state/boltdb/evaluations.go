@@ -0,0 +1,100 @@
+package boltdb
+
+import (
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// evaluationsBucket stores the evaluations by id
+var evaluationsBucket = []byte("evaluations")
+
+// UpsertEvaluation implements the State interface
+func (b *BoltDB) UpsertEvaluation(eval *proto.Evaluation) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return dbPut(tx.Bucket(evaluationsBucket), []byte(eval.Id), eval)
+	})
+}
+
+// GetEvaluation implements the State interface
+func (b *BoltDB) GetEvaluation(id string) (*proto.Evaluation, error) {
+	var eval *proto.Evaluation
+	err := b.db.View(func(tx *bolt.Tx) error {
+		obj := &proto.Evaluation{}
+		if err := dbGet(tx.Bucket(evaluationsBucket), []byte(id), obj); err != nil {
+			if err == errNotFound {
+				return nil
+			}
+			return err
+		}
+		eval = obj
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eval, nil
+}
+
+// ListEvaluations implements the State interface
+func (b *BoltDB) ListEvaluations(deploymentID string) ([]*proto.Evaluation, error) {
+	evals := []*proto.Evaluation{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		return forEachEvaluation(tx, func(eval *proto.Evaluation) error {
+			if deploymentID == "" || eval.DeploymentID == deploymentID {
+				evals = append(evals, eval)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	state.SortEvaluations(evals)
+	return evals, nil
+}
+
+func forEachEvaluation(tx *bolt.Tx, handler func(eval *proto.Evaluation) error) error {
+	return tx.Bucket(evaluationsBucket).ForEach(func(k, v []byte) error {
+		eval := &proto.Evaluation{}
+		if err := dbGet(tx.Bucket(evaluationsBucket), k, eval); err != nil {
+			return err
+		}
+		return handler(eval)
+	})
+}
+
+// removeEvaluations removes the evaluations for which remove returns true.
+// It returns the number of evaluations removed.
+func removeEvaluations(tx *bolt.Tx, remove func(eval *proto.Evaluation) bool) (int, error) {
+	// the bucket cannot be modified while iterating over it
+	ids := [][]byte{}
+	if err := forEachEvaluation(tx, func(eval *proto.Evaluation) error {
+		if remove(eval) {
+			ids = append(ids, []byte(eval.Id))
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := tx.Bucket(evaluationsBucket).Delete(id); err != nil {
+			return 0, err
+		}
+	}
+	return len(ids), nil
+}
+
+// compactEvaluations removes the evaluations that are out of the retention policy
+func compactEvaluations(tx *bolt.Tx, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) error {
+	num, err := removeEvaluations(tx, func(eval *proto.Evaluation) bool {
+		return policy.EvaluationExpired(eval, now)
+	})
+	if err != nil {
+		return err
+	}
+	stats.Evaluations += num
+	return nil
+}
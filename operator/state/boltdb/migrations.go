@@ -0,0 +1,194 @@
+package boltdb
+
+import (
+	"fmt"
+
+	"github.com/boltdb/bolt"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+var (
+	// systemBucket stores the metadata of the database
+	systemBucket = []byte("system")
+
+	// schemaVersionKey is the version of the layout of the database
+	schemaVersionKey = []byte("schemaVersion")
+)
+
+// migration changes the layout of the database from the previous version
+type migration struct {
+	desc string
+	fn   func(tx *bolt.Tx) error
+}
+
+// migrations is the ordered list of migrations. The migration at index i
+// moves the database from the version i to i+1. The databases created
+// before the schema version was introduced are at version 0.
+var migrations = []*migration{
+	{
+		desc: "track the stop time of the instances",
+		fn:   migrateInstanceStoppedAt,
+	},
+	{
+		desc: "store the id of the cluster component of the deployments",
+		fn:   migrateClusterKey,
+	},
+}
+
+// schemaVersion is the version of the layout of the database
+func schemaVersion() int64 {
+	return int64(len(migrations))
+}
+
+// migrate upgrades the layout of the database to the latest schema version.
+// The database is copied to <path>.v<version>.backup before any migration runs.
+func (b *BoltDB) migrate() error {
+	var version int64
+	var empty bool
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		if bkt := tx.Bucket(systemBucket); bkt != nil {
+			num, err := getSeqNumber(bkt, schemaVersionKey)
+			if err != nil {
+				return err
+			}
+			version = num
+			return nil
+		}
+		// a database without any bucket is a new one
+		empty = tx.Bucket(deploymentsBucket) == nil && tx.Bucket(instancesBucket) == nil
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if empty {
+		return b.setSchemaVersion(schemaVersion())
+	}
+	if version > schemaVersion() {
+		return fmt.Errorf("database schema version %d is newer than the supported version %d", version, schemaVersion())
+	}
+	if version == schemaVersion() {
+		return nil
+	}
+
+	backup := fmt.Sprintf("%s.v%d.backup", b.path, version)
+	err = b.db.View(func(tx *bolt.Tx) error {
+		return tx.CopyFile(backup, 0600)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to backup the database: %v", err)
+	}
+
+	for ; version < schemaVersion(); version++ {
+		m := migrations[version]
+
+		err := b.db.Update(func(tx *bolt.Tx) error {
+			if err := m.fn(tx); err != nil {
+				return err
+			}
+			return writeSchemaVersion(tx, version+1)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to migrate to version %d (%s): %v", version+1, m.desc, err)
+		}
+	}
+	return nil
+}
+
+func (b *BoltDB) setSchemaVersion(version int64) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return writeSchemaVersion(tx, version)
+	})
+}
+
+func writeSchemaVersion(tx *bolt.Tx, version int64) error {
+	bkt, err := tx.CreateBucketIfNotExists(systemBucket)
+	if err != nil {
+		return err
+	}
+	return putSeqNumber(bkt, schemaVersionKey, version)
+}
+
+// migrateInstanceStoppedAt sets the stop time of the instances that were
+// stopped before the field was introduced. Otherwise, they are removed on
+// the first compaction regardless of the ttl.
+func migrateInstanceStoppedAt(tx *bolt.Tx) error {
+	bkt := tx.Bucket(instancesBucket)
+	if bkt == nil {
+		return nil
+	}
+
+	now := ptypes.TimestampNow()
+
+	instances := []*proto.Instance{}
+	err := bkt.ForEach(func(k, v []byte) error {
+		instance := &proto.Instance{}
+		if err := dbGet(bkt, k, instance); err != nil {
+			return err
+		}
+		if instance.StoppedAt != nil {
+			return nil
+		}
+		if instance.Status == proto.Instance_STOPPED || instance.Status == proto.Instance_OUT {
+			instances = append(instances, instance)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, instance := range instances {
+		instance.StoppedAt = now
+		if err := dbPut(bkt, []byte(instance.ID), instance); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateClusterKey stores the id of the cluster component in each deployment.
+// Before, the cluster was the first component in the bucket but this is not
+// true if a resource gets a lower ULID within the same millisecond.
+func migrateClusterKey(tx *bolt.Tx) error {
+	depsBkt := tx.Bucket(deploymentsBucket)
+	if depsBkt == nil {
+		return nil
+	}
+
+	clusters := map[string][]byte{}
+	err := depsBkt.ForEach(func(k, v []byte) error {
+		compsBkt := depsBkt.Bucket(k).Bucket([]byte("components"))
+		if compsBkt == nil {
+			return nil
+		}
+		return compsBkt.ForEach(func(compID, v []byte) error {
+			seqs, err := seqKeys(compsBkt.Bucket(compID))
+			if err != nil {
+				return err
+			}
+			if len(seqs) == 0 {
+				return nil
+			}
+			comp := &proto.Component{}
+			if err := dbGet(compsBkt.Bucket(compID), seqID(seqs[0]), comp); err != nil {
+				return err
+			}
+			if comp.Type() == proto.EvaluationTypeCluster {
+				clusters[string(k)] = append([]byte{}, compID...)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	for id, compID := range clusters {
+		if err := depsBkt.Bucket([]byte(id)).Put(clusterKey, compID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
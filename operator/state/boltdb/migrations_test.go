@@ -0,0 +1,218 @@
+package boltdb
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/boltdb/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/lib/uuid"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// migrationFixture is a database with the layout of a schema version
+// and the checks to run once it is migrated to the latest version
+type migrationFixture struct {
+	version int64
+	write   func(t *testing.T, tx *bolt.Tx)
+	check   func(t *testing.T, b *BoltDB)
+}
+
+var migrationFixtures = []*migrationFixture{
+	{
+		// the layout before the schema version was introduced
+		version: 0,
+		write: func(t *testing.T, tx *bolt.Tx) {
+			depsBkt, err := tx.CreateBucket(deploymentsBucket)
+			assert.NoError(t, err)
+			instancesBkt, err := tx.CreateBucket(instancesBucket)
+			assert.NoError(t, err)
+			_, err = tx.CreateBucket(componentsBucket)
+			assert.NoError(t, err)
+
+			depBkt, err := depsBkt.CreateBucket([]byte("dep1"))
+			assert.NoError(t, err)
+			assert.NoError(t, dbPut(depBkt, depKey, &proto.Deployment{Id: "dep1", Name: "name1"}))
+
+			compsBkt, err := depBkt.CreateBucket([]byte("components"))
+			assert.NoError(t, err)
+			compBkt, err := compsBkt.CreateBucket([]byte("comp1"))
+			assert.NoError(t, err)
+			assert.NoError(t, dbPut(compBkt, seqID(1), &proto.Component{
+				Id:       "comp1",
+				Name:     "name1",
+				Sequence: 1,
+				Status:   proto.Component_APPLIED,
+				Spec:     proto.MustMarshalAny(&proto.ClusterSpec{}),
+			}))
+
+			reqs, err := depBkt.CreateBucket([]byte("requests"))
+			assert.NoError(t, err)
+			assert.NoError(t, reqs.Put(seqID(1), []byte("comp1#1")))
+			assert.NoError(t, putSeqNumber(reqs, nextAppliedKey, 2))
+
+			_, err = depBkt.CreateBucket([]byte("depends"))
+			assert.NoError(t, err)
+
+			instances := []*proto.Instance{
+				{ID: "i0", DeploymentID: "dep1", Status: proto.Instance_RUNNING},
+				{ID: "i1", DeploymentID: "dep1", Status: proto.Instance_OUT},
+			}
+			for _, i := range instances {
+				assert.NoError(t, dbPut(instancesBkt, []byte(i.ID), i))
+				assert.NoError(t, depBkt.Put([]byte(nodePrefix+i.ID), nil))
+			}
+		},
+		check: func(t *testing.T, b *BoltDB) {
+			// the stop time is set only for the stopped instances
+			i0, err := b.LoadNode("i0")
+			assert.NoError(t, err)
+			assert.Nil(t, i0.StoppedAt)
+
+			i1, err := b.LoadNode("i1")
+			assert.NoError(t, err)
+			assert.NotNil(t, i1.StoppedAt)
+
+			depID, err := b.NameToDeployment("name1")
+			assert.NoError(t, err)
+			assert.Equal(t, depID, "dep1")
+
+			dep, err := b.LoadDeployment("dep1")
+			assert.NoError(t, err)
+			assert.Len(t, dep.Instances, 1)
+		},
+	},
+	{
+		// the cluster is not the first component of the deployment
+		version: 1,
+		write: func(t *testing.T, tx *bolt.Tx) {
+			depsBkt, err := tx.CreateBucket(deploymentsBucket)
+			assert.NoError(t, err)
+			_, err = tx.CreateBucket(instancesBucket)
+			assert.NoError(t, err)
+
+			depBkt, err := depsBkt.CreateBucket([]byte("dep1"))
+			assert.NoError(t, err)
+			compsBkt, err := depBkt.CreateBucket([]byte("components"))
+			assert.NoError(t, err)
+
+			comps := []*proto.Component{
+				{
+					Id:   "comp1",
+					Name: "r1",
+					Spec: proto.MustMarshalAny(&proto.ResourceSpec{Cluster: "name1"}),
+				},
+				{
+					Id:   "comp2",
+					Name: "name1",
+					Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+				},
+			}
+			for _, comp := range comps {
+				comp.Sequence = 1
+				comp.Status = proto.Component_APPLIED
+
+				compBkt, err := compsBkt.CreateBucket([]byte(comp.Id))
+				assert.NoError(t, err)
+				assert.NoError(t, dbPut(compBkt, seqID(1), comp))
+			}
+		},
+		check: func(t *testing.T, b *BoltDB) {
+			comp, err := b.ReadDeployment("dep1")
+			assert.NoError(t, err)
+			if assert.NotNil(t, comp) {
+				assert.Equal(t, comp.Id, "comp2")
+			}
+		},
+	},
+}
+
+func readSchemaVersion(t *testing.T, path string) int64 {
+	db, err := bolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	var version int64
+	err = db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(systemBucket)
+		if bkt == nil {
+			return nil
+		}
+		version, err = getSeqNumber(bkt, schemaVersionKey)
+		return err
+	})
+	assert.NoError(t, err)
+	return version
+}
+
+func writeFixture(t *testing.T, path string, fn func(t *testing.T, tx *bolt.Tx)) {
+	db, err := bolt.Open(path, 0600, nil)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.NoError(t, db.Update(func(tx *bolt.Tx) error {
+		fn(t, tx)
+		return nil
+	}))
+}
+
+func TestMigrate_Fixtures(t *testing.T) {
+	// every migration has to be tested with a database of the previous version
+	assert.Len(t, migrationFixtures, len(migrations))
+
+	for _, f := range migrationFixtures {
+		path := "/tmp/db-" + uuid.UUID()
+		writeFixture(t, path, func(t *testing.T, tx *bolt.Tx) {
+			f.write(t, tx)
+			if f.version != 0 {
+				assert.NoError(t, writeSchemaVersion(tx, f.version))
+			}
+		})
+
+		b := testBoltdb(t, path)
+		f.check(t, b)
+		assert.NoError(t, b.Close())
+
+		assert.Equal(t, readSchemaVersion(t, path), schemaVersion())
+
+		// the backup keeps the database as it was before the migration
+		backup := fmt.Sprintf("%s.v%d.backup", path, f.version)
+		_, err := os.Stat(backup)
+		assert.NoError(t, err)
+		assert.Equal(t, readSchemaVersion(t, backup), f.version)
+	}
+}
+
+func TestMigrate_NewDatabase(t *testing.T) {
+	path := "/tmp/db-" + uuid.UUID()
+
+	b := testBoltdb(t, path)
+	assert.NoError(t, b.Close())
+
+	assert.Equal(t, readSchemaVersion(t, path), schemaVersion())
+
+	// there is no backup for a new database
+	_, err := os.Stat(path + ".v0.backup")
+	assert.True(t, os.IsNotExist(err))
+
+	// opening the database again does not run any migration
+	b = testBoltdb(t, path)
+	assert.NoError(t, b.Close())
+
+	_, err = os.Stat(path + ".v0.backup")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestMigrate_NewerVersion(t *testing.T) {
+	path := "/tmp/db-" + uuid.UUID()
+
+	writeFixture(t, path, func(t *testing.T, tx *bolt.Tx) {
+		assert.NoError(t, writeSchemaVersion(tx, schemaVersion()+1))
+	})
+
+	_, err := Factory(map[string]interface{}{
+		"path": path,
+	})
+	assert.Error(t, err)
+}
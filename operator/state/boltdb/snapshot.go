@@ -0,0 +1,257 @@
+package boltdb
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/boltdb/bolt"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+const (
+	dependsOnPrefix    = "depends-on-"
+	dependencyOfPrefix = "dependency-of-"
+	nodePrefix         = "node-"
+)
+
+// Snapshot implements the State interface
+func (b *BoltDB) Snapshot() (*proto.Snapshot, error) {
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	snap := &proto.Snapshot{
+		Version:     state.SnapshotVersion,
+		Timestamp:   ptypes.TimestampNow(),
+		Deployments: []*proto.Snapshot_Entry{},
+		Instances:   []*proto.Instance{},
+	}
+
+	err = tx.Bucket(deploymentsBucket).ForEach(func(k, v []byte) error {
+		entry, err := snapshotDeployment(string(k), tx.Bucket(deploymentsBucket).Bucket(k))
+		if err != nil {
+			return err
+		}
+		snap.Deployments = append(snap.Deployments, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Bucket(instancesBucket).ForEach(func(k, v []byte) error {
+		instance := &proto.Instance{}
+		if err := gproto.Unmarshal(v, instance); err != nil {
+			return err
+		}
+		snap.Instances = append(snap.Instances, instance)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func snapshotDeployment(id string, depBkt *bolt.Bucket) (*proto.Snapshot_Entry, error) {
+	entry := &proto.Snapshot_Entry{
+		Id: id,
+	}
+
+	dep := &proto.Deployment{}
+	if err := dbGet(depBkt, depKey, dep); err == nil {
+		entry.Deployment = dep
+	} else if err != errNotFound {
+		return nil, err
+	}
+
+	// the node and dependency references are stored as keys
+	err := depBkt.ForEach(func(k, v []byte) error {
+		if strings.HasPrefix(string(k), nodePrefix) {
+			entry.Instances = append(entry.Instances, strings.TrimPrefix(string(k), nodePrefix))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if dependsBkt := depBkt.Bucket([]byte("depends")); dependsBkt != nil {
+		err := dependsBkt.ForEach(func(k, v []byte) error {
+			if strings.HasPrefix(string(k), dependsOnPrefix) {
+				entry.DependsOn = append(entry.DependsOn, strings.TrimPrefix(string(k), dependsOnPrefix))
+			} else if strings.HasPrefix(string(k), dependencyOfPrefix) {
+				entry.DependencyOf = append(entry.DependencyOf, strings.TrimPrefix(string(k), dependencyOfPrefix))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if compsBkt := depBkt.Bucket([]byte("components")); compsBkt != nil {
+		clusterID := depBkt.Get(clusterKey)
+
+		err := compsBkt.ForEach(func(k, v []byte) error {
+			versions := []*proto.Component{}
+			err := compsBkt.Bucket(k).ForEach(func(k, v []byte) error {
+				comp := &proto.Component{}
+				if err := gproto.Unmarshal(v, comp); err != nil {
+					return err
+				}
+				versions = append(versions, comp)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			// the keys are not sorted by number (i.e. seq-10 goes before seq-2)
+			sort.Slice(versions, func(i, j int) bool {
+				return versions[i].Sequence < versions[j].Sequence
+			})
+			if bytes.Equal(k, clusterID) {
+				// the cluster goes first
+				entry.Components = append(versions, entry.Components...)
+			} else {
+				entry.Components = append(entry.Components, versions...)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if reqs := depBkt.Bucket([]byte("requests")); reqs != nil {
+		next, err := getSeqNumber(reqs, nextAppliedKey)
+		if err != nil {
+			return nil, err
+		}
+		entry.Next = next
+
+		for i := int64(1); ; i++ {
+			ref := reqs.Get(seqID(i))
+			if ref == nil {
+				break
+			}
+			entry.Requests = append(entry.Requests, string(ref))
+		}
+	}
+	return entry, nil
+}
+
+// Restore implements the State interface
+func (b *BoltDB) Restore(snap *proto.Snapshot) error {
+	tx, err := b.db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	depsBkt := tx.Bucket(deploymentsBucket)
+	instancesBkt := tx.Bucket(instancesBucket)
+
+	if k, _ := depsBkt.Cursor().First(); k != nil {
+		return fmt.Errorf("cannot restore a snapshot on a non empty state")
+	}
+	if k, _ := instancesBkt.Cursor().First(); k != nil {
+		return fmt.Errorf("cannot restore a snapshot on a non empty state")
+	}
+
+	for _, entry := range snap.Deployments {
+		depBkt, err := depsBkt.CreateBucket([]byte(entry.Id))
+		if err != nil {
+			return err
+		}
+		if err := restoreDeployment(depBkt, entry); err != nil {
+			return err
+		}
+	}
+	for _, instance := range snap.Instances {
+		if err := dbPut(instancesBkt, []byte(instance.ID), instance); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// queue the requests that were in progress
+	return b.queuePending()
+}
+
+func restoreDeployment(depBkt *bolt.Bucket, entry *proto.Snapshot_Entry) error {
+	if entry.Deployment != nil {
+		if err := dbPut(depBkt, depKey, entry.Deployment); err != nil {
+			return err
+		}
+	}
+	for _, id := range entry.Instances {
+		if err := depBkt.Put([]byte(nodePrefix+id), nil); err != nil {
+			return err
+		}
+	}
+
+	// the buckets for the components are only created on apply
+	if len(entry.Components) == 0 {
+		return nil
+	}
+
+	comps, err := depBkt.CreateBucket([]byte("components"))
+	if err != nil {
+		return err
+	}
+	for _, comp := range entry.Components {
+		compBkt, err := comps.CreateBucketIfNotExists([]byte(comp.Id))
+		if err != nil {
+			return err
+		}
+		if err := dbPut(compBkt, seqID(comp.Sequence), comp); err != nil {
+			return err
+		}
+		if comp.Type() == proto.EvaluationTypeCluster && depBkt.Get(clusterKey) == nil {
+			if err := depBkt.Put(clusterKey, []byte(comp.Id)); err != nil {
+				return err
+			}
+		}
+	}
+
+	reqs, err := depBkt.CreateBucket([]byte("requests"))
+	if err != nil {
+		return err
+	}
+	for indx, ref := range entry.Requests {
+		if err := reqs.Put(seqID2(indx+1), []byte(ref)); err != nil {
+			return err
+		}
+	}
+	if entry.Next != 0 {
+		if err := putSeqNumber(reqs, nextAppliedKey, entry.Next); err != nil {
+			return err
+		}
+	}
+
+	depends, err := depBkt.CreateBucket([]byte("depends"))
+	if err != nil {
+		return err
+	}
+	for _, id := range entry.DependsOn {
+		if err := depends.Put([]byte(dependsOnPrefix+id), nil); err != nil {
+			return err
+		}
+	}
+	for _, id := range entry.DependencyOf {
+		if err := depends.Put([]byte(dependencyOfPrefix+id), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
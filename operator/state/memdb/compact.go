@@ -0,0 +1,162 @@
+package memdb
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// Compact implements the State interface
+func (m *MemDB) Compact(policy *state.RetentionPolicy, now time.Time) (*state.CompactStats, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	stats := &state.CompactStats{}
+	for _, id := range m.deploymentIDs() {
+		purged, err := m.purgeDeployment(id, policy, now, stats)
+		if err != nil {
+			return nil, err
+		}
+		if purged {
+			continue
+		}
+		m.compactDeployment(m.deployments[id], policy, now, stats)
+	}
+
+	if policy.InstanceTTL != 0 {
+		for id, instance := range m.instances {
+			if !policy.InstanceExpired(instance, now) {
+				continue
+			}
+			delete(m.instances, id)
+			if dep, ok := m.deployments[instance.DeploymentID]; ok {
+				delete(dep.nodes, id)
+			}
+			stats.Instances++
+		}
+	}
+	m.compactEvaluations(policy, now, stats)
+	return stats, nil
+}
+
+// purgeDeployment removes the deployment if the deletion of the cluster is expired
+func (m *MemDB) purgeDeployment(id string, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) (bool, error) {
+	comp, err := m.readDeploymentCluster(id)
+	if err != nil {
+		return false, err
+	}
+	if comp == nil || !policy.DeleteExpired(comp, now) {
+		return false, nil
+	}
+	if m.queue.Exists(id) {
+		// there is a task in progress for the deployment
+		return false, nil
+	}
+
+	stats.Deployments++
+	stats.Instances += m.removeDeployment(id)
+	return true, nil
+}
+
+// removeDeployment removes the deployment, its instances and the references
+// to it in other deployments. It returns the number of instances removed.
+func (m *MemDB) removeDeployment(id string) int {
+	dep := m.deployments[id]
+	for node := range dep.nodes {
+		delete(m.instances, node)
+	}
+
+	// remove the references to this deployment in the other deployments
+	for other := range dep.dependsOn {
+		if otherDep, ok := m.deployments[other]; ok {
+			delete(otherDep.dependencyOf, id)
+		}
+	}
+	for other := range dep.dependencyOf {
+		if otherDep, ok := m.deployments[other]; ok {
+			delete(otherDep.dependsOn, id)
+		}
+	}
+
+	m.removeEvaluations(id)
+	delete(m.deployments, id)
+	return len(dep.nodes)
+}
+
+// compactDeployment removes the old versions of the components of the
+// deployment and the requests that reference them
+func (m *MemDB) compactDeployment(dep *deployment, policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) {
+	removed := map[string]struct{}{}
+
+	compOrder := []string{}
+	for indx, compID := range dep.compOrder {
+		versions := dep.components[compID]
+
+		// the first component is the cluster, which is only removed
+		// with the whole deployment
+		if indx != 0 && len(versions) != 0 && policy.DeleteExpired(versions[len(versions)-1], now) {
+			for _, comp := range versions {
+				removed[refKey(compID, comp.Sequence)] = struct{}{}
+			}
+			delete(dep.components, compID)
+			stats.Components += len(versions)
+			continue
+		}
+		compOrder = append(compOrder, compID)
+
+		var current int64
+		if dep.meta != nil && dep.meta.CompId == compID {
+			current = dep.meta.Sequence
+		}
+		seqs := map[int64]struct{}{}
+		for _, seq := range policy.RemovableVersions(versions, current) {
+			seqs[seq] = struct{}{}
+			removed[refKey(compID, seq)] = struct{}{}
+			stats.Components++
+		}
+		if len(seqs) == 0 {
+			continue
+		}
+		keep := []*proto.Component{}
+		for _, comp := range versions {
+			if _, ok := seqs[comp.Sequence]; !ok {
+				keep = append(keep, comp)
+			}
+		}
+		dep.components[compID] = keep
+	}
+	dep.compOrder = compOrder
+
+	if len(removed) == 0 {
+		return
+	}
+
+	// remove the requests and number the remaining ones from one again
+	requests := []string{}
+	var next int64
+	for indx, ref := range dep.requests {
+		if int64(indx+1) == dep.next {
+			// the next request is never applied yet and it is not removed
+			next = int64(len(requests) + 1)
+		}
+		if _, ok := removed[ref]; ok {
+			stats.Requests++
+			continue
+		}
+		requests = append(requests, ref)
+	}
+	if next == 0 {
+		// all the requests are applied
+		next = int64(len(requests) + 1)
+	}
+	dep.requests = requests
+	if dep.next != 0 {
+		dep.next = next
+	}
+}
+
+func refKey(compID string, seq int64) string {
+	return fmt.Sprintf("%s#%d", compID, seq)
+}
@@ -0,0 +1,182 @@
+package memdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// GetDependencies implements the State interface
+func (m *MemDB) GetDependencies(id string) ([]string, []string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[id]
+	if !ok {
+		return nil, nil, fmt.Errorf("deployment does not exists '%s'", id)
+	}
+	return sortedKeys(dep.dependsOn), sortedKeys(dep.dependencyOf), nil
+}
+
+// liveDependents returns the deployments that depend on this one and
+// are not deleted or being deleted
+func (m *MemDB) liveDependents(id string) []*proto.Component {
+	res := []*proto.Component{}
+	for _, other := range sortedKeys(m.deployments[id].dependencyOf) {
+		if last := m.readLastCluster(other); last != nil && last.Action != proto.Component_DELETE {
+			res = append(res, last)
+		}
+	}
+	return res
+}
+
+// dependentsDeleted returns true if the deletion of all the deployments
+// that depend on this one is applied
+func (m *MemDB) dependentsDeleted(id string) bool {
+	cluster := m.readLastCluster(id)
+	for other := range m.deployments[id].dependencyOf {
+		last := m.readLastCluster(other)
+		if last == nil {
+			// the dependent was purged
+			continue
+		}
+		if last.Action == proto.Component_DELETE && last.Status == proto.Component_APPLIED {
+			continue
+		}
+		if cluster != nil && cluster.Name == last.Name {
+			// a new generation of the cluster waits for this one instead
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// deleteDependents deletes the clusters that depend on the deployment if the
+// deletion is a cascade one. Otherwise, it fails if there is any of them.
+func (m *MemDB) deleteDependents(id string, comp *proto.Component) error {
+	dependents := m.liveDependents(id)
+	if len(dependents) == 0 {
+		return nil
+	}
+	if !comp.Cascade {
+		names := []string{}
+		for _, dependent := range dependents {
+			names = append(names, dependent.Name)
+		}
+		return fmt.Errorf("cluster %s is a dependency of %s, use a cascade delete to remove them too", comp.Name, strings.Join(names, ", "))
+	}
+	for _, dependent := range dependents {
+		del := &proto.Component{
+			Name:     dependent.Name,
+			Spec:     dependent.Spec,
+			Metadata: dependent.Metadata,
+			Action:   proto.Component_DELETE,
+			Cascade:  true,
+		}
+		if _, err := m.applyImpl(del); err != nil {
+			return fmt.Errorf("failed to delete the dependent cluster %s: %v", dependent.Name, err)
+		}
+	}
+	return nil
+}
+
+// unblockDeletes queues the deletion of the clusters the deployment depends on
+// that were waiting for the deletion of their dependents
+func (m *MemDB) unblockDeletes(dep *deployment) error {
+	for _, id := range sortedKeys(dep.dependsOn) {
+		comp, err := m.readDeploymentCluster(id)
+		if err != nil {
+			return err
+		}
+		if comp == nil || comp.Status != proto.Component_BLOCKED || comp.Action != proto.Component_DELETE {
+			continue
+		}
+		if !m.dependentsDeleted(id) {
+			continue
+		}
+		nextComp, err := m.updateComponentStatus(id, refKey(comp.Id, comp.Sequence), proto.Component_QUEUED)
+		if err != nil {
+			return err
+		}
+		m.addTask(id, nextComp)
+	}
+	return nil
+}
+
+// updateDependsOn resolves the dependencies of an existing cluster. It fails
+// if any of them would create a cycle. The references to the clusters that
+// are not a dependency anymore are removed.
+func (m *MemDB) updateDependsOn(id string, name string, dependsOn []string) ([]string, error) {
+	ids := []string{}
+	for _, dependency := range dependsOn {
+		other, err := m.nameToDeploymentID(dependency)
+		if err != nil {
+			return nil, err
+		}
+		if other == "" {
+			return nil, fmt.Errorf("dependency component %s not found", dependency)
+		}
+		if path := m.dependsPath(other, id); path != nil {
+			names := []string{name}
+			for _, elem := range path {
+				names = append(names, m.readLastCluster(elem).Name)
+			}
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(names, " -> "))
+		}
+		ids = append(ids, other)
+	}
+
+	// remove the dependencies that are not used anymore
+	dep := m.deployments[id]
+	for _, other := range sortedKeys(dep.dependsOn) {
+		found := false
+		for _, i := range ids {
+			if i == other {
+				found = true
+			}
+		}
+		if found {
+			continue
+		}
+		if comp := m.readLastCluster(other); comp != nil && comp.Name == name {
+			// keep the reference to the previous generation of the cluster
+			continue
+		}
+		delete(dep.dependsOn, other)
+		if otherDep, ok := m.deployments[other]; ok {
+			delete(otherDep.dependencyOf, id)
+		}
+	}
+	return ids, nil
+}
+
+// dependsPath returns the deployments in the path of dependencies
+// from one deployment to another (if any)
+func (m *MemDB) dependsPath(from, to string) []string {
+	visited := map[string]struct{}{}
+
+	var visit func(id string) []string
+	visit = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if _, ok := visited[id]; ok {
+			return nil
+		}
+		visited[id] = struct{}{}
+
+		dep, ok := m.deployments[id]
+		if !ok {
+			return nil
+		}
+		for _, other := range sortedKeys(dep.dependsOn) {
+			if path := visit(other); path != nil {
+				return append([]string{id}, path...)
+			}
+		}
+		return nil
+	}
+	return visit(from)
+}
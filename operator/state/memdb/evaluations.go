@@ -0,0 +1,63 @@
+package memdb
+
+import (
+	"time"
+
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// UpsertEvaluation implements the State interface
+func (m *MemDB) UpsertEvaluation(eval *proto.Evaluation) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.evaluations[eval.Id] = eval.Copy()
+	return nil
+}
+
+// GetEvaluation implements the State interface
+func (m *MemDB) GetEvaluation(id string) (*proto.Evaluation, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	eval, ok := m.evaluations[id]
+	if !ok {
+		return nil, nil
+	}
+	return eval.Copy(), nil
+}
+
+// ListEvaluations implements the State interface
+func (m *MemDB) ListEvaluations(deploymentID string) ([]*proto.Evaluation, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	evals := []*proto.Evaluation{}
+	for _, eval := range m.evaluations {
+		if deploymentID == "" || eval.DeploymentID == deploymentID {
+			evals = append(evals, eval.Copy())
+		}
+	}
+	state.SortEvaluations(evals)
+	return evals, nil
+}
+
+// compactEvaluations removes the evaluations that are out of the retention policy
+func (m *MemDB) compactEvaluations(policy *state.RetentionPolicy, now time.Time, stats *state.CompactStats) {
+	for id, eval := range m.evaluations {
+		if policy.EvaluationExpired(eval, now) {
+			delete(m.evaluations, id)
+			stats.Evaluations++
+		}
+	}
+}
+
+// removeEvaluations removes all the evaluations of the deployment
+func (m *MemDB) removeEvaluations(deploymentID string) {
+	for id, eval := range m.evaluations {
+		if eval.DeploymentID == deploymentID {
+			delete(m.evaluations, id)
+		}
+	}
+}
@@ -0,0 +1,836 @@
+package memdb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/teseraio/ensemble/lib/uuid"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// Factory is the factory method for the in-memory backend
+func Factory(config map[string]interface{}) (*MemDB, error) {
+	m := &MemDB{
+		deployments: map[string]*deployment{},
+		instances:   map[string]*proto.Instance{},
+		evaluations: map[string]*proto.Evaluation{},
+		queue:       state.NewTaskQueue(),
+		waitCh:      map[string]chan struct{}{},
+	}
+	return m, nil
+}
+
+var _ state.State = &MemDB{}
+
+// MemDB is an in-memory state implementation. The data is
+// lost once the process stops.
+type MemDB struct {
+	lock        sync.Mutex
+	deployments map[string]*deployment
+	instances   map[string]*proto.Instance
+	evaluations map[string]*proto.Evaluation
+	queue       *state.TaskQueue
+
+	waitChLock sync.Mutex
+	waitCh     map[string]chan struct{}
+}
+
+type deployment struct {
+	// meta is the deployment object (if any)
+	meta *proto.Deployment
+
+	// components are the versions of each component by id and
+	// compOrder the ids by creation order. The first one is the cluster.
+	components map[string][]*proto.Component
+	compOrder  []string
+
+	// requests are the component versions (id#sequence) in the
+	// order they are applied and next the index (from 1) of the next one
+	requests []string
+	next     int64
+
+	dependsOn    map[string]struct{}
+	dependencyOf map[string]struct{}
+
+	nodes map[string]struct{}
+}
+
+func newDeployment() *deployment {
+	return &deployment{
+		components:   map[string][]*proto.Component{},
+		requests:     []string{},
+		dependsOn:    map[string]struct{}{},
+		dependencyOf: map[string]struct{}{},
+		nodes:        map[string]struct{}{},
+	}
+}
+
+func (d *deployment) isPaused() bool {
+	return d.meta != nil && d.meta.Paused
+}
+
+// Close implements the State interface
+func (m *MemDB) Close() error {
+	return nil
+}
+
+// Wait implements the State interface
+func (m *MemDB) Wait(id string) chan struct{} {
+	m.waitChLock.Lock()
+	defer m.waitChLock.Unlock()
+
+	ch := make(chan struct{})
+	m.waitCh[id] = ch
+
+	return ch
+}
+
+// GetTask implements the State interface
+func (m *MemDB) GetTask(ctx context.Context) *proto.Task {
+	return m.queue.Pop(ctx)
+}
+
+func (m *MemDB) addTask(deploymentID string, comp *proto.Component) {
+	m.queue.Add(&proto.Task{
+		DeploymentID: deploymentID,
+		ComponentID:  comp.Id,
+		Sequence:     comp.Sequence,
+	})
+}
+
+func parseRef(ref string) (string, int64, error) {
+	spl := strings.Split(ref, "#")
+	if len(spl) != 2 {
+		return "", 0, fmt.Errorf("incorrect component reference %s", ref)
+	}
+	seq, err := strconv.Atoi(spl[1])
+	if err != nil {
+		return "", 0, err
+	}
+	return spl[0], int64(seq), nil
+}
+
+func (m *MemDB) getComponent(deploymentID, ref string, sequence int64) (*proto.Component, error) {
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return nil, fmt.Errorf("deployment %s not found", deploymentID)
+	}
+	versions, ok := dep.components[ref]
+	if !ok {
+		return nil, fmt.Errorf("component %s not found", ref)
+	}
+	// the versions are sorted but there might be gaps after a compaction
+	indx := sort.Search(len(versions), func(i int) bool {
+		return versions[i].Sequence >= sequence
+	})
+	if indx == len(versions) || versions[indx].Sequence != sequence {
+		return nil, fmt.Errorf("sequence %d not found for component %s", sequence, ref)
+	}
+	return versions[indx], nil
+}
+
+func (m *MemDB) updateComponentStatus(deploymentID string, compRef string, status proto.Component_Status) (*proto.Component, error) {
+	ref, seq, err := parseRef(compRef)
+	if err != nil {
+		return nil, err
+	}
+	comp, err := m.getComponent(deploymentID, ref, seq)
+	if err != nil {
+		return nil, err
+	}
+	comp.Status = status
+	return comp.Copy(), nil
+}
+
+// GetComponentByID2 implements the State interface
+func (m *MemDB) GetComponentByID2(deploymentID, ref string, sequence int64) (*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	comp, err := m.getComponent(deploymentID, ref, sequence)
+	if err != nil {
+		return nil, err
+	}
+	return comp.Copy(), nil
+}
+
+// GetHistory implements the State interface
+func (m *MemDB) GetHistory(deploymentID string) ([]*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return nil, fmt.Errorf("deployment %s not found", deploymentID)
+	}
+	result := []*proto.Component{}
+	for _, compRef := range dep.requests {
+		ref, seq, err := parseRef(compRef)
+		if err != nil {
+			return nil, err
+		}
+		comp, err := m.getComponent(deploymentID, ref, seq)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comp.Copy())
+	}
+	return result, nil
+}
+
+// GetComponentVersions implements the State interface
+func (m *MemDB) GetComponentVersions(deploymentID string, id string) ([]*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return nil, fmt.Errorf("deployment %s not found", deploymentID)
+	}
+	versions, ok := dep.components[id]
+	if !ok {
+		return nil, fmt.Errorf("component %s not found", id)
+	}
+	result := []*proto.Component{}
+	for _, comp := range versions {
+		result = append(result, comp.Copy())
+	}
+	return result, nil
+}
+
+// GetComponents implements the State interface
+func (m *MemDB) GetComponents(deploymentID string) ([]*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return nil, fmt.Errorf("deployment %s not found", deploymentID)
+	}
+	result := []*proto.Component{}
+	for _, id := range dep.compOrder {
+		comp, err := readLatestComponent(dep.components[id])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, comp.Copy())
+	}
+	return result, nil
+}
+
+// readLatestComponent returns the component currently being applied or
+// the last one applied
+func readLatestComponent(versions []*proto.Component) (*proto.Component, error) {
+	var past *proto.Component
+	for i := len(versions) - 1; i >= 0; i-- {
+		component := versions[i]
+		if component.Status == proto.Component_APPLIED {
+			if past == nil {
+				return component, nil
+			}
+			return past, nil
+		}
+		past = component
+	}
+	if past != nil {
+		return past, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (m *MemDB) readDeploymentCluster(id string) (*proto.Component, error) {
+	dep, ok := m.deployments[id]
+	if !ok || len(dep.compOrder) == 0 {
+		return nil, nil
+	}
+	// the first component is always the cluster
+	return readLatestComponent(dep.components[dep.compOrder[0]])
+}
+
+// readLastCluster returns the last version of the cluster component of the
+// deployment, regardless of whether it is applied or not
+func (m *MemDB) readLastCluster(id string) *proto.Component {
+	dep, ok := m.deployments[id]
+	if !ok || len(dep.compOrder) == 0 {
+		return nil
+	}
+	versions := dep.components[dep.compOrder[0]]
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+// dependenciesReady returns true if all the clusters the deployment depends
+// on are applied. A previous generation of the cluster is ready once its
+// deletion is applied.
+func (m *MemDB) dependenciesReady(deploymentID string) bool {
+	for id := range m.deployments[deploymentID].dependsOn {
+		dep, ok := m.deployments[id]
+		if !ok {
+			// the dependency was purged
+			continue
+		}
+		if len(dep.compOrder) == 0 {
+			return false
+		}
+		versions := dep.components[dep.compOrder[0]]
+
+		var applied bool
+		for _, comp := range versions {
+			if comp.Status == proto.Component_APPLIED {
+				applied = true
+			}
+		}
+		if !applied {
+			return false
+		}
+		last := versions[len(versions)-1]
+		if last.Action == proto.Component_DELETE && last.Status != proto.Component_APPLIED {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadDeployment implements the State interface
+func (m *MemDB) ReadDeployment(id string) (*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	comp, err := m.readDeploymentCluster(id)
+	if err != nil || comp == nil {
+		return nil, err
+	}
+	return comp.Copy(), nil
+}
+
+// NameToDeployment implements the State interface
+func (m *MemDB) NameToDeployment(name string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.nameToDeploymentID(name)
+}
+
+// nameToDeploymentID resolves the deployment of a cluster by name. If the
+// cluster is re-created while the deletion of the previous generation is
+// in progress, the new generation is returned.
+func (m *MemDB) nameToDeploymentID(name string) (string, error) {
+	var deploymentID, deletingID string
+	for _, id := range m.deploymentIDs() {
+		comp, err := m.readDeploymentCluster(id)
+		if err != nil {
+			return "", err
+		}
+		if comp == nil || comp.Action == proto.Component_DELETE {
+			continue
+		}
+		if comp.Name != name {
+			continue
+		}
+		if m.readLastCluster(id).Action == proto.Component_DELETE {
+			deletingID = id
+		} else {
+			deploymentID = id
+		}
+	}
+	if deploymentID == "" {
+		return deletingID, nil
+	}
+	return deploymentID, nil
+}
+
+// currentSequence returns the sequence of the latest version of the
+// component in the deployment or zero if it does not exists
+func (m *MemDB) currentSequence(deploymentID, name string) (int64, error) {
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return 0, nil
+	}
+	for _, id := range dep.compOrder {
+		component, err := readLatestComponent(dep.components[id])
+		if err != nil {
+			return 0, err
+		}
+		if component.Action == proto.Component_DELETE || component.Name != name {
+			continue
+		}
+		versions := dep.components[id]
+		return versions[len(versions)-1].Sequence, nil
+	}
+	return 0, nil
+}
+
+func (m *MemDB) deploymentIDs() []string {
+	ids := []string{}
+	for id := range m.deployments {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Apply implements the State interface
+func (m *MemDB) Apply(comp *proto.Component) (*proto.Component, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.applyImpl(comp)
+}
+
+func (m *MemDB) applyImpl(comp *proto.Component) (*proto.Component, error) {
+	msg, err := proto.UnmarshalAny(comp.Spec)
+	if err != nil {
+		return nil, err
+	}
+	clusterRef := msg.(proto.ClusterRef)
+
+	clusterName := clusterRef.GetCluster()
+	if clusterName == "" {
+		clusterName = comp.Name
+	}
+
+	var dependsOn []string
+	var dependsIDs []string
+
+	deploymentID, err := m.nameToDeploymentID(clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	// a cluster created again while the deletion of the previous one is in
+	// progress starts a new generation, blocked until the deletion is applied
+	var prevGeneration string
+	if deploymentID != "" && clusterRef.GetCluster() == "" && comp.Action == proto.Component_CREATE {
+		if last := m.readLastCluster(deploymentID); last != nil && last.Action == proto.Component_DELETE {
+			prevGeneration = deploymentID
+			deploymentID = ""
+		}
+	}
+
+	// check the expected sequence before any change is done
+	current, err := m.currentSequence(deploymentID, comp.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := state.CheckSequence(comp, current); err != nil {
+		return nil, err
+	}
+
+	isCluster := clusterRef.GetCluster() == ""
+	if isCluster && comp.Action == proto.Component_CREATE {
+		for _, name := range msg.(*proto.ClusterSpec).DependsOn {
+			if name == comp.Name {
+				return nil, fmt.Errorf("cluster %s cannot depend on itself", name)
+			}
+		}
+	}
+
+	// the clusters that depend on this one have to be deleted first
+	var waitDependents bool
+	if deploymentID != "" && isCluster && comp.Action == proto.Component_DELETE {
+		if err := m.deleteDependents(deploymentID, comp); err != nil {
+			return nil, err
+		}
+		waitDependents = !m.dependentsDeleted(deploymentID)
+	}
+
+	if deploymentID != "" && isCluster && comp.Action == proto.Component_CREATE {
+		// the dependencies of an existing cluster are updated
+		ids, err := m.updateDependsOn(deploymentID, comp.Name, msg.(*proto.ClusterSpec).DependsOn)
+		if err != nil {
+			return nil, err
+		}
+		dependsIDs = ids
+	}
+
+	dep := m.deployments[deploymentID]
+	if deploymentID == "" {
+		// only create a new deployment if its a cluster
+		if clusterRef.GetCluster() != "" {
+			return nil, fmt.Errorf("cluster not found")
+		}
+		for _, name := range msg.(*proto.ClusterSpec).DependsOn {
+			id, err := m.nameToDeploymentID(name)
+			if err != nil {
+				return nil, err
+			}
+			if id == "" {
+				return nil, fmt.Errorf("dependency component %s not found", name)
+			}
+
+			// check if the component has finished
+			depComp, err := m.readDeploymentCluster(id)
+			if err != nil {
+				return nil, err
+			}
+			dependsIDs = append(dependsIDs, id)
+			if depComp.Status != proto.Component_APPLIED {
+				dependsOn = append(dependsOn, id)
+			}
+		}
+		if prevGeneration != "" {
+			dependsIDs = append(dependsIDs, prevGeneration)
+			dependsOn = append(dependsOn, prevGeneration)
+		}
+		deploymentID = uuid.UUID()
+		dep = newDeployment()
+	}
+
+	comp = comp.Copy()
+	comp.Id = ""
+	comp.ExpectedSequence = 0
+
+	// find the resource with the same name (if any)
+	for _, id := range dep.compOrder {
+		component, err := readLatestComponent(dep.components[id])
+		if err != nil {
+			return nil, err
+		}
+		if component.Action == proto.Component_DELETE {
+			continue
+		}
+		if component.Name == comp.Name {
+			comp.Id = id
+		}
+	}
+	if comp.Id == "" {
+		if comp.Id, err = uuid.ULID(); err != nil {
+			return nil, err
+		}
+	}
+
+	// the component is queued if there are no pending requests to be applied
+	addEval := dep.next == 0 || dep.next == int64(len(dep.requests)+1)
+	if addEval && !dep.isPaused() {
+		if len(dependsOn) == 0 && !waitDependents {
+			comp.Status = proto.Component_QUEUED
+		} else {
+			comp.Status = proto.Component_BLOCKED
+		}
+	} else {
+		comp.Status = proto.Component_PENDING
+	}
+
+	versions := dep.components[comp.Id]
+	if len(versions) != 0 {
+		prev := versions[len(versions)-1]
+		if prev.Action == proto.Component_DELETE {
+			// a delete object cannot be created again
+			return nil, fmt.Errorf("the object was deleted")
+		} else if prev.Action == proto.Component_CREATE && comp.Action != proto.Component_DELETE {
+			// make sure we dont try to save the same spec
+			equal, err := proto.Cmp(prev.Spec, comp.Spec)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				return nil, nil
+			}
+		}
+	} else if comp.Action == proto.Component_DELETE {
+		return nil, fmt.Errorf("cannot remove non created object")
+	}
+
+	comp.Timestamp = ptypes.TimestampNow()
+	comp.Sequence = 1
+	if len(versions) != 0 {
+		comp.Sequence = versions[len(versions)-1].Sequence + 1
+	}
+
+	// commit the changes
+	m.deployments[deploymentID] = dep
+	for _, id := range dependsIDs {
+		dep.dependsOn[id] = struct{}{}
+		m.deployments[id].dependencyOf[deploymentID] = struct{}{}
+	}
+	if len(versions) == 0 {
+		dep.compOrder = append(dep.compOrder, comp.Id)
+	}
+	dep.components[comp.Id] = append(versions, comp)
+	dep.requests = append(dep.requests, fmt.Sprintf("%s#%d", comp.Id, comp.Sequence))
+	if dep.next == 0 {
+		dep.next = 1
+	}
+
+	if comp.Status == proto.Component_QUEUED {
+		m.addTask(deploymentID, comp)
+	}
+	return comp.Copy(), nil
+}
+
+// Finalize implements the State interface
+func (m *MemDB) Finalize(deploymentID string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.queue.Finalize(deploymentID); !ok {
+		return fmt.Errorf("task not found for deployment %s", deploymentID)
+	}
+	dep, ok := m.deployments[deploymentID]
+	if !ok {
+		return fmt.Errorf("deployment %s not found", deploymentID)
+	}
+
+	num := dep.next
+	if num < 1 || num > int64(len(dep.requests)) {
+		return fmt.Errorf("request not found for seq %d", num)
+	}
+	comp, err := m.updateComponentStatus(deploymentID, dep.requests[num-1], proto.Component_APPLIED)
+	if err != nil {
+		return err
+	}
+	dep.next = num + 1
+
+	// check the next one
+	if num < int64(len(dep.requests)) && !dep.isPaused() {
+		// there is a new one, eval it unless it is the deletion of the
+		// cluster and there are clusters that depend on it
+		status := proto.Component_QUEUED
+
+		ref, seq, err := parseRef(dep.requests[num])
+		if err != nil {
+			return err
+		}
+		if nextComp, err := m.getComponent(deploymentID, ref, seq); err != nil {
+			return err
+		} else if nextComp.Action == proto.Component_DELETE && dep.compOrder[0] == nextComp.Id && !m.dependentsDeleted(deploymentID) {
+			status = proto.Component_BLOCKED
+		}
+		nextComp, err := m.updateComponentStatus(deploymentID, dep.requests[num], status)
+		if err != nil {
+			return err
+		}
+		if status == proto.Component_QUEUED {
+			m.addTask(deploymentID, nextComp)
+		}
+	}
+
+	// check any dependency if we finished the first execution or the
+	// deletion of the cluster (a new generation might be waiting)
+	if comp.Sequence == 1 || (comp.Action == proto.Component_DELETE && dep.compOrder[0] == comp.Id) {
+		blocked := []string{}
+		for id := range dep.dependencyOf {
+			blocked = append(blocked, id)
+		}
+		sort.Strings(blocked)
+
+		for _, blockedDeploymentID := range blocked {
+			blockedComp, err := m.readDeploymentCluster(blockedDeploymentID)
+			if err != nil {
+				return err
+			}
+			if blockedComp.Status != proto.Component_BLOCKED || blockedComp.Action == proto.Component_DELETE {
+				continue
+			}
+			if m.dependenciesReady(blockedDeploymentID) {
+				nextComp, err := m.updateComponentStatus(blockedDeploymentID, blockedComp.Id+"#"+strconv.Itoa(int(blockedComp.Sequence)), proto.Component_QUEUED)
+				if err != nil {
+					return err
+				}
+				m.addTask(blockedDeploymentID, nextComp)
+			}
+		}
+	}
+
+	// the deletion of the clusters this one depends on might be waiting for it
+	if comp.Action == proto.Component_DELETE && dep.compOrder[0] == comp.Id {
+		if err := m.unblockDeletes(dep); err != nil {
+			return err
+		}
+	}
+
+	m.waitChLock.Lock()
+	if ch, ok := m.waitCh[comp.Id]; ok {
+		close(ch)
+		delete(m.waitCh, comp.Id)
+	}
+	m.waitChLock.Unlock()
+
+	return nil
+}
+
+// ListDeployments implements the State interface
+func (m *MemDB) ListDeployments() ([]*proto.Deployment, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var deps []*proto.Deployment
+	for _, id := range m.deploymentIDs() {
+		deps = append(deps, m.loadDeploymentImpl(id, false))
+	}
+	return deps, nil
+}
+
+// LoadDeployment implements the State interface
+func (m *MemDB) LoadDeployment(id string) (*proto.Deployment, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.loadDeploymentImpl(id, true), nil
+}
+
+func (m *MemDB) loadDeploymentImpl(id string, includeInstances bool) *proto.Deployment {
+	dep, ok := m.deployments[id]
+	if !ok {
+		return nil
+	}
+	comp, err := m.readDeploymentCluster(id)
+	if err != nil {
+		return nil
+	}
+	if dep.meta == nil {
+		c := &proto.Deployment{
+			Instances: []*proto.Instance{},
+			Id:        id,
+		}
+		if comp != nil {
+			c.Name = comp.Name
+		}
+		return c
+	}
+
+	c := dep.meta.Copy()
+	if includeInstances {
+		ids := []string{}
+		for id := range dep.nodes {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			n := m.instances[id]
+			if n.Status != proto.Instance_OUT {
+				c.Instances = append(c.Instances, n.Copy())
+			}
+		}
+	}
+	return c
+}
+
+// UpdateDeployment implements the State interface
+func (m *MemDB) UpdateDeployment(d *proto.Deployment) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[d.Id]
+	if !ok {
+		dep = newDeployment()
+		m.deployments[d.Id] = dep
+	}
+	dd := d.Copy()
+	dd.Instances = nil
+
+	// the paused flag is only modified with SetPaused
+	dd.Paused = dep.isPaused()
+	dd.ModifyIndex = 1
+	if dep.meta != nil {
+		dd.ModifyIndex = dep.meta.ModifyIndex + 1
+	}
+	dep.meta = dd
+
+	return nil
+}
+
+// SetPaused implements the State interface
+func (m *MemDB) SetPaused(id string, paused bool) (*proto.Deployment, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[id]
+	if !ok {
+		return nil, fmt.Errorf("deployment does not exists '%s'", id)
+	}
+	if dep.meta == nil {
+		comp, err := m.readDeploymentCluster(id)
+		if err != nil {
+			return nil, err
+		}
+		dep.meta = &proto.Deployment{
+			Id: id,
+		}
+		if comp != nil {
+			dep.meta.Name = comp.Name
+		}
+	}
+	dep.meta.Paused = paused
+	dep.meta.ModifyIndex++
+
+	if !paused && !m.queue.Exists(id) && dep.next >= 1 && dep.next <= int64(len(dep.requests)) {
+		ref, seq, err := parseRef(dep.requests[dep.next-1])
+		if err != nil {
+			return nil, err
+		}
+		comp, err := m.getComponent(id, ref, seq)
+		if err != nil {
+			return nil, err
+		}
+		if comp.Status == proto.Component_PENDING || comp.Status == proto.Component_QUEUED {
+			comp.Status = proto.Component_QUEUED
+			m.addTask(id, comp)
+		}
+	}
+	return dep.meta.Copy(), nil
+}
+
+// Purge implements the State interface
+func (m *MemDB) Purge(id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	comp, err := m.readDeploymentCluster(id)
+	if err != nil {
+		return err
+	}
+	if comp == nil {
+		return fmt.Errorf("deployment does not exists '%s'", id)
+	}
+	if comp.Action != proto.Component_DELETE || comp.Status != proto.Component_APPLIED {
+		return fmt.Errorf("deployment '%s' is not deleted", id)
+	}
+	if m.queue.Exists(id) {
+		return fmt.Errorf("deployment '%s' has a task in progress", id)
+	}
+	m.removeDeployment(id)
+	return nil
+}
+
+// LoadNode implements the State interface
+func (m *MemDB) LoadNode(id string) (*proto.Instance, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	n, ok := m.instances[id]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return n.Copy(), nil
+}
+
+// UpsertNode implements the State interface
+func (m *MemDB) UpsertNode(n *proto.Instance) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	dep, ok := m.deployments[n.DeploymentID]
+	if !ok {
+		return fmt.Errorf("deployment does not exists '%s'", n.DeploymentID)
+	}
+	nn := n.Copy()
+	nn.ModifyIndex = 1
+	if prev, ok := m.instances[n.ID]; ok {
+		nn.ModifyIndex = prev.ModifyIndex + 1
+	}
+	dep.nodes[n.ID] = struct{}{}
+	m.instances[n.ID] = nn
+
+	return nil
+}
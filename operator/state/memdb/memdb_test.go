@@ -0,0 +1,17 @@
+package memdb
+
+import (
+	"testing"
+
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+func TestSuite(t *testing.T) {
+	state.TestSuite(t, func(t *testing.T) state.State {
+		st, err := Factory(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return st
+	})
+}
@@ -0,0 +1,131 @@
+package memdb
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/teseraio/ensemble/operator/proto"
+	"github.com/teseraio/ensemble/operator/state"
+)
+
+// Snapshot implements the State interface
+func (m *MemDB) Snapshot() (*proto.Snapshot, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	snap := &proto.Snapshot{
+		Version:     state.SnapshotVersion,
+		Timestamp:   ptypes.TimestampNow(),
+		Deployments: []*proto.Snapshot_Entry{},
+		Instances:   []*proto.Instance{},
+	}
+	for _, id := range m.deploymentIDs() {
+		dep := m.deployments[id]
+
+		entry := &proto.Snapshot_Entry{
+			Id:           id,
+			Requests:     append([]string{}, dep.requests...),
+			Next:         dep.next,
+			DependsOn:    sortedKeys(dep.dependsOn),
+			DependencyOf: sortedKeys(dep.dependencyOf),
+			Instances:    sortedKeys(dep.nodes),
+		}
+		if dep.meta != nil {
+			entry.Deployment = dep.meta.Copy()
+		}
+		for _, compID := range dep.compOrder {
+			for _, comp := range dep.components[compID] {
+				entry.Components = append(entry.Components, comp.Copy())
+			}
+		}
+		snap.Deployments = append(snap.Deployments, entry)
+	}
+
+	ids := []string{}
+	for id := range m.instances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		snap.Instances = append(snap.Instances, m.instances[id].Copy())
+	}
+	return snap, nil
+}
+
+// Restore implements the State interface
+func (m *MemDB) Restore(snap *proto.Snapshot) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if len(m.deployments) != 0 || len(m.instances) != 0 {
+		return fmt.Errorf("cannot restore a snapshot on a non empty state")
+	}
+
+	deployments := map[string]*deployment{}
+	for _, entry := range snap.Deployments {
+		dep := newDeployment()
+		if entry.Deployment != nil {
+			dep.meta = entry.Deployment.Copy()
+		}
+		for _, comp := range entry.Components {
+			versions, ok := dep.components[comp.Id]
+			if !ok {
+				dep.compOrder = append(dep.compOrder, comp.Id)
+			}
+			if len(versions) != 0 && comp.Sequence <= versions[len(versions)-1].Sequence {
+				return fmt.Errorf("unexpected sequence %d for component %s", comp.Sequence, comp.Id)
+			}
+			dep.components[comp.Id] = append(versions, comp.Copy())
+		}
+		dep.requests = append(dep.requests, entry.Requests...)
+		dep.next = entry.Next
+		for _, id := range entry.DependsOn {
+			dep.dependsOn[id] = struct{}{}
+		}
+		for _, id := range entry.DependencyOf {
+			dep.dependencyOf[id] = struct{}{}
+		}
+		for _, id := range entry.Instances {
+			dep.nodes[id] = struct{}{}
+		}
+		deployments[entry.Id] = dep
+	}
+	instances := map[string]*proto.Instance{}
+	for _, instance := range snap.Instances {
+		instances[instance.ID] = instance.Copy()
+	}
+	m.deployments = deployments
+	m.instances = instances
+
+	// queue the requests that were in progress
+	for _, id := range m.deploymentIDs() {
+		dep := m.deployments[id]
+		if dep.isPaused() || dep.next < 1 || dep.next > int64(len(dep.requests)) {
+			continue
+		}
+		ref, seq, err := parseRef(dep.requests[dep.next-1])
+		if err != nil {
+			return err
+		}
+		comp, err := m.getComponent(id, ref, seq)
+		if err != nil {
+			return err
+		}
+		if comp.Status == proto.Component_BLOCKED {
+			// it is queued once the dependencies are applied
+			continue
+		}
+		m.addTask(id, comp)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
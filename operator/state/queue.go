@@ -1,4 +1,4 @@
-package boltdb
+package state
 
 import (
 	"container/heap"
@@ -19,22 +19,26 @@ type task struct {
 	timestamp time.Time
 }
 
-type taskQueue struct {
+// TaskQueue is the queue of the deployment tasks to process. There is at most
+// one task per deployment and it is not released until it is finalized.
+type TaskQueue struct {
 	heap     taskQueueImpl
 	lock     sync.Mutex
 	items    map[string]*task
 	updateCh chan struct{}
 }
 
-func newTaskQueue() *taskQueue {
-	return &taskQueue{
+// NewTaskQueue creates a new TaskQueue
+func NewTaskQueue() *TaskQueue {
+	return &TaskQueue{
 		heap:     taskQueueImpl{},
 		items:    map[string]*task{},
 		updateCh: make(chan struct{}),
 	}
 }
 
-func (t *taskQueue) add(pTask *proto.Task) {
+// Add adds a task to the queue
+func (t *TaskQueue) Add(pTask *proto.Task) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -53,7 +57,16 @@ func (t *taskQueue) add(pTask *proto.Task) {
 	}
 }
 
-func (t *taskQueue) popImpl() *task {
+// TryPop returns the next ready task without blocking
+func (t *TaskQueue) TryPop() *proto.Task {
+	tt := t.popImpl()
+	if tt == nil {
+		return nil
+	}
+	return tt.Task
+}
+
+func (t *TaskQueue) popImpl() *task {
 	t.lock.Lock()
 	if len(t.heap) != 0 && t.heap[0].ready {
 		// pop the first value and remove it from the heap
@@ -68,11 +81,13 @@ func (t *taskQueue) popImpl() *task {
 	return nil
 }
 
-func (t *taskQueue) pop(ctx context.Context) *task {
+// Pop returns the next ready task. It blocks until there is a task
+// available or the context is done.
+func (t *TaskQueue) Pop(ctx context.Context) *proto.Task {
 POP:
 	tt := t.popImpl()
 	if tt != nil {
-		return tt
+		return tt.Task
 	}
 
 	select {
@@ -83,7 +98,17 @@ POP:
 	}
 }
 
-func (t *taskQueue) finalize(clusterID string) (*task, bool) {
+// Exists returns whether there is a task for the deployment
+func (t *TaskQueue) Exists(clusterID string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.items[clusterID]
+	return ok
+}
+
+// Finalize removes the task of the deployment from the queue
+func (t *TaskQueue) Finalize(clusterID string) (*proto.Task, bool) {
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
@@ -96,7 +121,7 @@ func (t *taskQueue) finalize(clusterID string) (*task, bool) {
 	heap.Remove(&t.heap, item.index)
 	delete(t.items, item.clusterID)
 
-	return item, true
+	return item.Task, true
 }
 
 type taskQueueImpl []*task
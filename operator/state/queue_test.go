@@ -1,4 +1,4 @@
-package boltdb
+package state
 
 import (
 	"testing"
@@ -8,24 +8,24 @@ import (
 )
 
 func TestQueue(t *testing.T) {
-	q := newTaskQueue()
+	q := NewTaskQueue()
 
 	assert.Nil(t, q.popImpl())
 
-	q.add(&proto.Task{
+	q.Add(&proto.Task{
 		DeploymentID: "id1",
 	})
 
 	assert.NotNil(t, q.popImpl())
 	assert.Nil(t, q.popImpl())
 
-	q.add(&proto.Task{
+	q.Add(&proto.Task{
 		DeploymentID: "id2",
 	})
 
 	assert.NotNil(t, q.popImpl())
 
-	_, ok := q.finalize("id1")
+	_, ok := q.Finalize("id1")
 	assert.True(t, ok)
 
 	assert.Nil(t, q.popImpl())
@@ -0,0 +1,125 @@
+package state
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// RetentionPolicy is the policy used to compact the history of the state.
+// A zero value in any of the fields keeps the objects forever.
+type RetentionPolicy struct {
+	// ComponentVersions is the number of versions to keep for each component
+	ComponentVersions int64
+
+	// InstanceTTL is the time to keep the instances that are out
+	// of the cluster
+	InstanceTTL time.Duration
+
+	// DeleteTTL is the time to keep the deployments and the resources
+	// once their deletion is applied
+	DeleteTTL time.Duration
+
+	// EvaluationTTL is the time to keep the evaluations once they finish
+	EvaluationTTL time.Duration
+}
+
+// CompactStats is the number of objects removed during a compaction
+type CompactStats struct {
+	Components  int
+	Requests    int
+	Instances   int
+	Deployments int
+	Evaluations int
+}
+
+// Empty returns true if the compaction did not remove any object
+func (c *CompactStats) Empty() bool {
+	return c.Components == 0 && c.Requests == 0 && c.Instances == 0 && c.Deployments == 0 && c.Evaluations == 0
+}
+
+// DeleteExpired returns true if the component is an applied deletion older
+// than the DeleteTTL of the policy
+func (p *RetentionPolicy) DeleteExpired(comp *proto.Component, now time.Time) bool {
+	if p.DeleteTTL == 0 {
+		return false
+	}
+	if comp.Action != proto.Component_DELETE || comp.Status != proto.Component_APPLIED {
+		return false
+	}
+	return expired(comp.Timestamp, p.DeleteTTL, now)
+}
+
+// InstanceExpired returns true if the instance is out of the cluster for
+// longer than the InstanceTTL of the policy
+func (p *RetentionPolicy) InstanceExpired(i *proto.Instance, now time.Time) bool {
+	if p.InstanceTTL == 0 {
+		return false
+	}
+	if i.Status != proto.Instance_OUT {
+		return false
+	}
+	if i.StoppedAt == nil {
+		// instances stopped before the field was introduced
+		return true
+	}
+	return expired(i.StoppedAt, p.InstanceTTL, now)
+}
+
+// EvaluationExpired returns true if the evaluation finished before
+// the EvaluationTTL of the policy
+func (p *RetentionPolicy) EvaluationExpired(eval *proto.Evaluation, now time.Time) bool {
+	if p.EvaluationTTL == 0 {
+		return false
+	}
+	if eval.Status == proto.Evaluation_PENDING {
+		return false
+	}
+	if eval.CompletedAt == nil {
+		return true
+	}
+	return expired(eval.CompletedAt, p.EvaluationTTL, now)
+}
+
+// RemovableVersions returns the sequences of the versions of a component
+// that can be removed. The versions must be sorted by sequence and current
+// is the sequence in use by the deployment (if any). The latest applied
+// version and the versions that are not yet applied are always kept.
+func (p *RetentionPolicy) RemovableVersions(versions []*proto.Component, current int64) []int64 {
+	if p.ComponentVersions == 0 || len(versions) == 0 {
+		return nil
+	}
+
+	var lastApplied int64
+	for _, v := range versions {
+		if v.Status == proto.Component_APPLIED {
+			lastApplied = v.Sequence
+		}
+	}
+
+	limit := versions[len(versions)-1].Sequence - p.ComponentVersions
+	res := []int64{}
+	for _, v := range versions {
+		if v.Sequence > limit {
+			break
+		}
+		if v.Status != proto.Component_APPLIED {
+			continue
+		}
+		if v.Sequence == lastApplied || v.Sequence == current {
+			continue
+		}
+		res = append(res, v.Sequence)
+	}
+	return res
+}
+
+func expired(ts *timestamp.Timestamp, ttl time.Duration, now time.Time) bool {
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return true
+	}
+	return now.Sub(t) > ttl
+}
@@ -3,6 +3,8 @@ package state
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/teseraio/ensemble/operator/proto"
 )
@@ -10,31 +12,132 @@ import (
 // Factory is the method to initialize the state
 type Factory func(map[string]interface{}) (State, error)
 
-// TODO: Remove, we are using now the boltdb directly
-
 // State stores the state of the Ensemble server
 type State interface {
-	Apply(*proto.Component) (int64, error)
+	// Apply stores a new version of a component. It returns nil if the
+	// component does not change with respect to the last version. If the
+	// expected sequence is set and it does not match the latest version
+	// of the component, it returns a ConflictError.
+	Apply(*proto.Component) (*proto.Component, error)
+
+	// Finalize marks the current task of the deployment as applied
+	// and queues the next one (if any)
+	Finalize(deploymentID string) error
+
+	// GetTask returns the next task to process. It blocks until there
+	// is a task or the context is done.
+	GetTask(ctx context.Context) *proto.Task
+
+	// Wait returns a channel that is closed once the component is finalized
+	Wait(id string) chan struct{}
+
+	// GetComponentByID2 returns a specific version of a component
+	GetComponentByID2(deploymentID, ref string, sequence int64) (*proto.Component, error)
+
+	// GetComponentVersions returns all the versions of a component
+	GetComponentVersions(deploymentID string, id string) ([]*proto.Component, error)
+
+	// GetComponents returns the latest version of all the components of a deployment
+	GetComponents(deploymentID string) ([]*proto.Component, error)
+
+	// GetHistory returns all the components applied to a deployment in order
+	GetHistory(deploymentID string) ([]*proto.Component, error)
 
-	// GetComponent(id string) (*proto.Component, error)
-	GetComponent(namespace, id string, sequence int64) (*proto.Component, error)
-	GetComponentByID(namespace, name string, compID string) (*proto.Component, error)
+	// ReadDeployment returns the cluster component of a deployment
+	ReadDeployment(id string) (*proto.Component, error)
 
-	Finalize(id string) error
-	GetPending(id string) (*proto.Component, error)
-	GetTask(ctx context.Context) *proto.Component
-	GetTask2(ctx context.Context) *proto.Task
+	// NameToDeployment resolves the id of a deployment by name
+	NameToDeployment(name string) (string, error)
 
 	ListDeployments() ([]*proto.Deployment, error)
+	LoadDeployment(id string) (*proto.Deployment, error)
+
+	// UpdateDeployment writes the deployment and increases its modify index
 	UpdateDeployment(d *proto.Deployment) error
+
+	// SetPaused pauses or resumes the reconciliation of a deployment
+	SetPaused(id string, paused bool) (*proto.Deployment, error)
+
+	// GetDependencies returns the ids of the deployments the deployment
+	// depends on and the ones that depend on it
+	GetDependencies(id string) (dependsOn []string, dependencyOf []string, err error)
+
+	// Purge removes a deployment whose deletion is applied and all its history
+	Purge(id string) error
+
+	LoadNode(id string) (*proto.Instance, error)
+
+	// UpsertNode writes the instance and increases its modify index
 	UpsertNode(n *proto.Instance) error
-	LoadDeployment(id string) (*proto.Deployment, error)
-	LoadInstance(cluster, id string) (*proto.Instance, error)
+
+	// UpsertEvaluation writes an evaluation
+	UpsertEvaluation(eval *proto.Evaluation) error
+
+	// GetEvaluation returns an evaluation by id. It returns nil
+	// if the evaluation does not exist.
+	GetEvaluation(id string) (*proto.Evaluation, error)
+
+	// ListEvaluations returns the evaluations of a deployment sorted by creation
+	// time. If the id is empty, it returns the evaluations of all the deployments.
+	ListEvaluations(deploymentID string) ([]*proto.Evaluation, error)
+
+	// Snapshot returns a consistent copy of all the data in the state
+	// except the evaluations, which are only kept for inspection
+	Snapshot() (*proto.Snapshot, error)
+
+	// Restore writes the data of a snapshot. The state must be empty.
+	Restore(snap *proto.Snapshot) error
+
+	// Compact removes the history of the state that is out of the
+	// retention policy
+	Compact(policy *RetentionPolicy, now time.Time) (*CompactStats, error)
 
 	// Close closes the state
 	Close() error
 }
 
+// SnapshotVersion is the current version of the snapshot format
+const SnapshotVersion = 1
+
 var (
 	ErrClusterNotFound = fmt.Errorf("cluster not found")
 )
+
+// ConflictError is returned when a component is applied with an
+// expected sequence that is not the latest one
+type ConflictError struct {
+	Name     string
+	Expected int64
+	Current  int64
+}
+
+func (c *ConflictError) Error() string {
+	return fmt.Sprintf("conflict applying %s: expected sequence %d but the latest is %d", c.Name, c.Expected, c.Current)
+}
+
+// CheckSequence returns a ConflictError if the component expects a sequence
+// different from the current one
+func CheckSequence(comp *proto.Component, current int64) error {
+	if comp.ExpectedSequence == 0 || comp.ExpectedSequence == current {
+		return nil
+	}
+	return &ConflictError{
+		Name:     comp.Name,
+		Expected: comp.ExpectedSequence,
+		Current:  current,
+	}
+}
+
+// SortEvaluations sorts the evaluations by creation time
+func SortEvaluations(evals []*proto.Evaluation) {
+	sort.SliceStable(evals, func(i, j int) bool {
+		a, b := evals[i].CreatedAt, evals[j].CreatedAt
+		if a.GetSeconds() != b.GetSeconds() {
+			return a.GetSeconds() < b.GetSeconds()
+		}
+		if a.GetNanos() != b.GetNanos() {
+			return a.GetNanos() < b.GetNanos()
+		}
+		return evals[i].Id < evals[j].Id
+	})
+}
@@ -0,0 +1,1206 @@
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gproto "github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// TestSuite is the conformance test suite that any State implementation
+// has to pass. The factory must return a new empty state on each call.
+func TestSuite(t *testing.T, factory func(t *testing.T) State) {
+	cases := map[string]func(t *testing.T, st State){
+		"ListDeployments":              testListDeployments,
+		"ApplyFirst_CannotDelete":      testApplyFirstCannotDelete,
+		"ApplySecond_SameComponent":    testApplySecondSameComponent,
+		"ApplySecond_FinalizeFirst":    testApplySecondFinalizeFirst,
+		"ApplySecond_FirstQueued":      testApplySecondFirstQueued,
+		"ApplyDelete_ClusterReuseName": testApplyDeleteClusterReuseName,
+		"ApplyResourceUnknownCluster":  testApplyResourceUnknownCluster,
+		"ReadDeployment":               testReadDeployment,
+		"History":                      testHistory,
+		"DependsOn_PendingComponent":   testDependsOnPendingComponent,
+		"DependsOn_ComponentNotExists": testDependsOnComponentNotExists,
+		"Deployment_UpsertInstance":    testDeploymentUpsertInstance,
+		"Pause_ApplyPending":           testPauseApplyPending,
+		"Wait":                         testWait,
+		"Compact_Versions":             testCompactVersions,
+		"Compact_Instances":            testCompactInstances,
+		"Compact_DeletedDeployment":    testCompactDeletedDeployment,
+		"Recreate_DeletedCluster":      testRecreateDeletedCluster,
+		"Purge":                        testPurge,
+		"Delete_RefuseDependents":      testDeleteRefuseDependents,
+		"Delete_Cascade":               testDeleteCascade,
+		"DependsOn_Cycle":              testDependsOnCycle,
+		"Apply_ExpectedSequence":       testApplyExpectedSequence,
+		"ModifyIndex":                  testModifyIndex,
+		"Evaluations":                  testEvaluations,
+		"Compact_Evaluations":          testCompactEvaluations,
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			st := factory(t)
+			defer st.Close()
+
+			c(t, st)
+		})
+	}
+	t.Run("Snapshot", func(t *testing.T) {
+		testSnapshot(t, factory)
+	})
+}
+
+// popTask returns the next ready task without blocking
+func popTask(st State) *proto.Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	return st.GetTask(ctx)
+}
+
+func testListDeployments(t *testing.T, st State) {
+	comp, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(1))
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	dep, err := st.LoadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, dep.Name, "name1")
+
+	dep.Backend = "xxx"
+	assert.NoError(t, st.UpdateDeployment(dep))
+
+	deps, err := st.ListDeployments()
+	assert.NoError(t, err)
+	assert.Len(t, deps, 1)
+	assert.Equal(t, deps[0].Id, depID)
+	assert.Equal(t, deps[0].Name, "name1")
+
+	dep, err = st.LoadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, dep.Backend, "xxx")
+}
+
+func testApplyFirstCannotDelete(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.Error(t, err)
+}
+
+func testApplySecondSameComponent(t *testing.T, st State) {
+	spec := &proto.ClusterSpec{
+		Groups: []*proto.ClusterSpec_Group{
+			{Count: 1},
+		},
+	}
+
+	comp0, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(spec),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp0.Sequence, int64(1))
+
+	// the same spec does not create a new version
+	comp1, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(spec),
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, comp1)
+}
+
+func testApplySecondFinalizeFirst(t *testing.T, st State) {
+	comp0, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	_, err = st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	// there is only one task per deployment at a time
+	task1 := popTask(st)
+	assert.NotNil(t, task1)
+	assert.Equal(t, task1.DeploymentID, depID)
+	assert.Equal(t, task1.ComponentID, comp0.Id)
+	assert.Nil(t, popTask(st))
+
+	assert.NoError(t, st.Finalize(depID))
+
+	task2 := popTask(st)
+	assert.NotNil(t, task2)
+	assert.Equal(t, task2.ComponentID, comp0.Id)
+	assert.Equal(t, task2.Sequence, int64(2))
+
+	vers, err := st.GetComponentVersions(depID, comp0.Id)
+	assert.NoError(t, err)
+	assert.Len(t, vers, 2)
+	assert.Equal(t, vers[0].Status, proto.Component_APPLIED)
+	assert.Equal(t, vers[1].Status, proto.Component_QUEUED)
+
+	comp, err := st.GetComponentByID2(depID, comp0.Id, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(2))
+
+	_, err = st.GetComponentByID2(depID, comp0.Id, 3)
+	assert.Error(t, err)
+}
+
+func testApplySecondFirstQueued(t *testing.T, st State) {
+	comp0, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp0.Status, proto.Component_QUEUED)
+
+	comp1, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp1.Id, comp0.Id)
+	assert.Equal(t, comp1.Sequence, int64(2))
+	assert.Equal(t, comp1.Status, proto.Component_PENDING)
+}
+
+func testApplyDeleteClusterReuseName(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	comp, err := st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(2))
+
+	// the deleted object is created again in a new deployment
+	comp2, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp2.Sequence, int64(1))
+	assert.NotEqual(t, comp2.Id, comp.Id)
+
+	// the object cannot be deleted twice
+	_, err = st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	_, err = st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.Error(t, err)
+}
+
+func testApplyResourceUnknownCluster(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "r1",
+		Spec: proto.MustMarshalAny(&proto.ResourceSpec{
+			Cluster: "name1",
+		}),
+	})
+	assert.Error(t, err)
+}
+
+func testReadDeployment(t *testing.T, st State) {
+	for i := 0; i < 3; i++ {
+		comp, err := st.Apply(&proto.Component{
+			Name: "name1",
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				Groups: []*proto.ClusterSpec_Group{
+					{Count: int64(i)},
+				},
+			}),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, comp.Sequence, int64(i+1))
+	}
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	// sequence=1 is the current pending deployment
+	comp, err := st.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(1))
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	// sequence=2 is the new pending deployment
+	comp, err = st.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(2))
+
+	// unknown deployment
+	comp, err = st.ReadDeployment("unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, comp)
+}
+
+func testHistory(t *testing.T, st State) {
+	cluster, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	resource, err := st.Apply(&proto.Component{
+		Name: "r1",
+		Spec: proto.MustMarshalAny(&proto.ResourceSpec{
+			Cluster: "name1",
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, resource.Status, proto.Component_PENDING)
+
+	history, err := st.GetHistory(depID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, history[0].Id, cluster.Id)
+	assert.Equal(t, history[1].Id, resource.Id)
+
+	comps, err := st.GetComponents(depID)
+	assert.NoError(t, err)
+	assert.Len(t, comps, 2)
+}
+
+func testDependsOnPendingComponent(t *testing.T, st State) {
+	comp1, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp1.Status, proto.Component_QUEUED)
+
+	comp2, err := st.Apply(&proto.Component{
+		Name: "name2",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name1"},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp2.Status, proto.Component_BLOCKED)
+
+	task1 := popTask(st)
+	assert.NotNil(t, task1)
+	assert.Equal(t, task1.ComponentID, comp1.Id)
+	assert.Nil(t, popTask(st))
+
+	// it should trigger the 'name2' component
+	assert.NoError(t, st.Finalize(task1.DeploymentID))
+
+	task2 := popTask(st)
+	assert.NotNil(t, task2)
+	assert.Equal(t, task2.ComponentID, comp2.Id)
+	assert.NoError(t, st.Finalize(task2.DeploymentID))
+
+	// the dependency is already applied
+	comp3, err := st.Apply(&proto.Component{
+		Name: "name3",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name2"},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp3.Status, proto.Component_QUEUED)
+}
+
+func testDependsOnComponentNotExists(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"comp-1"},
+		}),
+	})
+	assert.Error(t, err)
+}
+
+func testDeploymentUpsertInstance(t *testing.T, st State) {
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	// the deployment must exist
+	assert.Error(t, st.UpsertNode(&proto.Instance{
+		ID:           "i1",
+		DeploymentID: "dep2",
+	}))
+
+	assert.NoError(t, st.UpsertNode(&proto.Instance{
+		ID:           "i0",
+		DeploymentID: "dep1",
+	}))
+
+	i0, err := st.LoadNode("i0")
+	assert.NoError(t, err)
+	assert.Equal(t, i0.ID, "i0")
+
+	_, err = st.LoadNode("i1")
+	assert.Error(t, err)
+
+	dep, err := st.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Len(t, dep.Instances, 1)
+	assert.Equal(t, dep.Instances[0].ID, "i0")
+
+	// the instances that are out are not loaded
+	i0.Status = proto.Instance_OUT
+	assert.NoError(t, st.UpsertNode(i0))
+
+	dep, err = st.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Len(t, dep.Instances, 0)
+}
+
+func testModifyIndex(t *testing.T, st State) {
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	dep, err := st.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Equal(t, dep.ModifyIndex, int64(1))
+
+	// the index is increased on each write regardless of the input
+	assert.NoError(t, st.UpdateDeployment(dep))
+	assert.NoError(t, st.UpdateDeployment(dep))
+
+	dep, err = st.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Equal(t, dep.ModifyIndex, int64(3))
+
+	dep, err = st.SetPaused("dep1", true)
+	assert.NoError(t, err)
+	assert.Equal(t, dep.ModifyIndex, int64(4))
+
+	i0 := &proto.Instance{
+		ID:           "i0",
+		DeploymentID: "dep1",
+	}
+	assert.NoError(t, st.UpsertNode(i0))
+
+	i0, err = st.LoadNode("i0")
+	assert.NoError(t, err)
+	assert.Equal(t, i0.ModifyIndex, int64(1))
+
+	i0.ModifyIndex = 10
+	assert.NoError(t, st.UpsertNode(i0))
+
+	i0, err = st.LoadNode("i0")
+	assert.NoError(t, err)
+	assert.Equal(t, i0.ModifyIndex, int64(2))
+}
+
+func testPauseApplyPending(t *testing.T, st State) {
+	comp0, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	dep, err := st.SetPaused(depID, true)
+	assert.NoError(t, err)
+	assert.True(t, dep.Paused)
+	assert.Equal(t, dep.Name, "name1")
+
+	comp1, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp1.Status, proto.Component_PENDING)
+	assert.Nil(t, popTask(st))
+
+	// the flag is not modified by the deployment updates
+	dep, err = st.LoadDeployment(depID)
+	assert.NoError(t, err)
+	dep.Paused = false
+	assert.NoError(t, st.UpdateDeployment(dep))
+
+	dep, err = st.LoadDeployment(depID)
+	assert.NoError(t, err)
+	assert.True(t, dep.Paused)
+
+	_, err = st.SetPaused(depID, false)
+	assert.NoError(t, err)
+
+	task := popTask(st)
+	assert.NotNil(t, task)
+	assert.Equal(t, task.ComponentID, comp0.Id)
+	assert.Equal(t, task.Sequence, int64(2))
+
+	_, err = st.SetPaused("unknown", true)
+	assert.Error(t, err)
+}
+
+func testWait(t *testing.T, st State) {
+	comp, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	waitCh := st.Wait(comp.Id)
+
+	task := popTask(st)
+	assert.NotNil(t, task)
+	assert.NoError(t, st.Finalize(task.DeploymentID))
+
+	select {
+	case <-waitCh:
+	default:
+		t.Fatal("wait channel not closed")
+	}
+
+	// there is no task to finalize
+	assert.Error(t, st.Finalize(task.DeploymentID))
+}
+
+func testSnapshot(t *testing.T, factory func(t *testing.T) State) {
+	st := factory(t)
+	defer st.Close()
+
+	comp0, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id:      depID,
+		Name:    "name1",
+		Backend: "xxx",
+	}))
+	assert.NoError(t, st.UpsertNode(&proto.Instance{
+		ID:           "i0",
+		DeploymentID: depID,
+	}))
+
+	// the first version is in progress and the second one is pending
+	assert.NotNil(t, popTask(st))
+
+	_, err = st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			Groups: []*proto.ClusterSpec_Group{
+				{Count: 1},
+			},
+		}),
+	})
+	assert.NoError(t, err)
+
+	comp2, err := st.Apply(&proto.Component{
+		Name: "name2",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name1"},
+		}),
+	})
+	assert.NoError(t, err)
+
+	snap, err := st.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, snap.Version, int64(SnapshotVersion))
+
+	// the snapshot can only be restored in an empty state
+	assert.Error(t, st.Restore(snap))
+
+	st2 := factory(t)
+	defer st2.Close()
+
+	assert.NoError(t, st2.Restore(snap))
+
+	// the restored state has the same data
+	snap2, err := st2.Snapshot()
+	assert.NoError(t, err)
+	snap2.Timestamp = snap.Timestamp
+	assert.True(t, gproto.Equal(snap, snap2))
+
+	dep, err := st2.LoadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, dep.Backend, "xxx")
+	assert.Len(t, dep.Instances, 1)
+
+	vers, err := st2.GetComponentVersions(depID, comp0.Id)
+	assert.NoError(t, err)
+	assert.Len(t, vers, 2)
+
+	depID2, err := st2.NameToDeployment("name2")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, depID2)
+
+	// the request in progress is queued again but not the blocked one
+	task := popTask(st2)
+	assert.NotNil(t, task)
+	assert.Equal(t, task.DeploymentID, depID)
+	assert.Equal(t, task.Sequence, int64(1))
+	assert.Nil(t, popTask(st2))
+
+	// the pending requests and the dependencies are restored
+	assert.NoError(t, st2.Finalize(depID))
+
+	tasks := map[string]*proto.Task{}
+	for i := 0; i < 2; i++ {
+		if task := popTask(st2); assert.NotNil(t, task) {
+			tasks[task.DeploymentID] = task
+		}
+	}
+	if assert.Contains(t, tasks, depID) {
+		assert.Equal(t, tasks[depID].Sequence, int64(2))
+	}
+	if assert.Contains(t, tasks, depID2) {
+		assert.Equal(t, tasks[depID2].ComponentID, comp2.Id)
+	}
+}
+
+func testCompactVersions(t *testing.T, st State) {
+	var cluster *proto.Component
+	for i := 0; i < 12; i++ {
+		comp, err := st.Apply(&proto.Component{
+			Name: "name1",
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				Groups: []*proto.ClusterSpec_Group{
+					{Count: int64(i)},
+				},
+			}),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, comp.Sequence, int64(i+1))
+		cluster = comp
+	}
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	// apply all the versions but the last one
+	for i := 0; i < 11; i++ {
+		task := popTask(st)
+		if assert.NotNil(t, task) {
+			assert.Equal(t, task.Sequence, int64(i+1))
+		}
+		assert.NoError(t, st.Finalize(depID))
+	}
+
+	vers, err := st.GetComponentVersions(depID, cluster.Id)
+	assert.NoError(t, err)
+	assert.Len(t, vers, 12)
+	assert.Equal(t, vers[11].Sequence, int64(12))
+
+	// the version in use by the deployment is not removed
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id:       depID,
+		CompId:   cluster.Id,
+		Sequence: 2,
+	}))
+
+	stats, err := st.Compact(&RetentionPolicy{ComponentVersions: 3}, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, stats.Components, 8)
+	assert.Equal(t, stats.Requests, 8)
+
+	vers, err = st.GetComponentVersions(depID, cluster.Id)
+	assert.NoError(t, err)
+
+	seqs := []int64{}
+	for _, v := range vers {
+		seqs = append(seqs, v.Sequence)
+	}
+	assert.Equal(t, seqs, []int64{2, 10, 11, 12})
+
+	history, err := st.GetHistory(depID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 4)
+
+	_, err = st.GetComponentByID2(depID, cluster.Id, 5)
+	assert.Error(t, err)
+
+	// the pending version is applied after the compaction
+	task := popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.Sequence, int64(12))
+	}
+	assert.NoError(t, st.Finalize(depID))
+
+	comp, err := st.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(12))
+	assert.Equal(t, comp.Status, proto.Component_APPLIED)
+
+	comp, err = st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(13))
+	assert.Equal(t, comp.Status, proto.Component_QUEUED)
+}
+
+func testCompactInstances(t *testing.T, st State) {
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	now := time.Now()
+	timestamp := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(now.Add(-d))
+		return ts
+	}
+
+	instances := []*proto.Instance{
+		{ID: "i0", Status: proto.Instance_RUNNING},
+		{ID: "i1", Status: proto.Instance_OUT, StoppedAt: timestamp(2 * time.Hour)},
+		{ID: "i2", Status: proto.Instance_OUT, StoppedAt: timestamp(time.Minute)},
+		{ID: "i3", Status: proto.Instance_OUT},
+	}
+	for _, i := range instances {
+		i.DeploymentID = "dep1"
+		assert.NoError(t, st.UpsertNode(i))
+	}
+
+	// instances are kept if there is no ttl
+	stats, err := st.Compact(&RetentionPolicy{}, now)
+	assert.NoError(t, err)
+	assert.True(t, stats.Empty())
+
+	stats, err = st.Compact(&RetentionPolicy{InstanceTTL: time.Hour}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stats.Instances, 2)
+
+	for _, id := range []string{"i0", "i2"} {
+		_, err := st.LoadNode(id)
+		assert.NoError(t, err)
+	}
+	for _, id := range []string{"i1", "i3"} {
+		_, err := st.LoadNode(id)
+		assert.Error(t, err)
+	}
+}
+
+func testEvaluations(t *testing.T, st State) {
+	now := time.Now()
+	timestamp := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(now.Add(d))
+		return ts
+	}
+
+	evals := []*proto.Evaluation{
+		{Id: "e2", DeploymentID: "dep1", CreatedAt: timestamp(2 * time.Second)},
+		{Id: "e0", DeploymentID: "dep1", CreatedAt: timestamp(0)},
+		{Id: "e1", DeploymentID: "dep2", CreatedAt: timestamp(time.Second)},
+	}
+	for _, eval := range evals {
+		assert.NoError(t, st.UpsertEvaluation(eval))
+	}
+
+	// the plan is stored with the evaluation
+	evals[1].Status = proto.Evaluation_COMPLETE
+	evals[1].Plan = &proto.Plan{
+		NodeUpdate: []*proto.Instance{
+			{ID: "i0"},
+		},
+	}
+	assert.NoError(t, st.UpsertEvaluation(evals[1]))
+
+	eval, err := st.GetEvaluation("e0")
+	assert.NoError(t, err)
+	assert.Equal(t, eval.Status, proto.Evaluation_COMPLETE)
+	assert.Len(t, eval.Plan.NodeUpdate, 1)
+
+	eval, err = st.GetEvaluation("e3")
+	assert.NoError(t, err)
+	assert.Nil(t, eval)
+
+	ids := func(evals []*proto.Evaluation) []string {
+		res := []string{}
+		for _, eval := range evals {
+			res = append(res, eval.Id)
+		}
+		return res
+	}
+
+	// sorted by creation time
+	list, err := st.ListEvaluations("")
+	assert.NoError(t, err)
+	assert.Equal(t, ids(list), []string{"e0", "e1", "e2"})
+
+	list, err = st.ListEvaluations("dep1")
+	assert.NoError(t, err)
+	assert.Equal(t, ids(list), []string{"e0", "e2"})
+}
+
+func testCompactEvaluations(t *testing.T, st State) {
+	now := time.Now()
+	timestamp := func(d time.Duration) *timestamp.Timestamp {
+		ts, _ := ptypes.TimestampProto(now.Add(-d))
+		return ts
+	}
+
+	evals := []*proto.Evaluation{
+		{Id: "e0", Status: proto.Evaluation_PENDING},
+		{Id: "e1", Status: proto.Evaluation_COMPLETE, CompletedAt: timestamp(2 * time.Hour)},
+		{Id: "e2", Status: proto.Evaluation_FAILED, CompletedAt: timestamp(time.Minute)},
+		{Id: "e3", Status: proto.Evaluation_CANCELLED, CompletedAt: timestamp(3 * time.Hour)},
+	}
+	for _, eval := range evals {
+		eval.DeploymentID = "dep1"
+		assert.NoError(t, st.UpsertEvaluation(eval))
+	}
+
+	// evaluations are kept if there is no ttl
+	stats, err := st.Compact(&RetentionPolicy{}, now)
+	assert.NoError(t, err)
+	assert.True(t, stats.Empty())
+
+	stats, err = st.Compact(&RetentionPolicy{EvaluationTTL: time.Hour}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, stats.Evaluations, 2)
+
+	for id, exists := range map[string]bool{"e0": true, "e1": false, "e2": true, "e3": false} {
+		eval, err := st.GetEvaluation(id)
+		assert.NoError(t, err)
+		assert.Equal(t, eval != nil, exists, id)
+	}
+}
+
+func testCompactDeletedDeployment(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	_, err = st.Apply(&proto.Component{
+		Name: "name2",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name1"},
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID2, err := st.NameToDeployment("name2")
+	assert.NoError(t, err)
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID2))
+
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id: depID,
+	}))
+	assert.NoError(t, st.UpsertNode(&proto.Instance{
+		ID:           "i0",
+		DeploymentID: depID,
+	}))
+
+	_, err = st.Apply(&proto.Component{
+		Name:    "name1",
+		Action:  proto.Component_DELETE,
+		Spec:    proto.MustMarshalAny(&proto.ClusterSpec{}),
+		Cascade: true,
+	})
+	assert.NoError(t, err)
+
+	// the dependent is deleted first
+	task := popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID2)
+	}
+	assert.NoError(t, st.Finalize(depID2))
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	policy := &RetentionPolicy{DeleteTTL: time.Hour}
+
+	// the deletion is not expired yet
+	stats, err := st.Compact(policy, time.Now())
+	assert.NoError(t, err)
+	assert.True(t, stats.Empty())
+
+	stats, err = st.Compact(policy, time.Now().Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, stats.Deployments, 2)
+	assert.Equal(t, stats.Instances, 1)
+
+	comp, err := st.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Nil(t, comp)
+
+	_, err = st.LoadNode("i0")
+	assert.Error(t, err)
+
+	deps, err := st.ListDeployments()
+	assert.NoError(t, err)
+	assert.Len(t, deps, 0)
+
+	snap, err := st.Snapshot()
+	assert.NoError(t, err)
+	assert.Len(t, snap.Deployments, 0)
+}
+
+func testRecreateDeletedCluster(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	_, err = st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	// the new generation waits for the deletion of the previous one
+	comp, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(1))
+	assert.Equal(t, comp.Status, proto.Component_BLOCKED)
+
+	depID2, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+	assert.NotEqual(t, depID, depID2)
+
+	// the creation of the previous generation does not unblock it
+	task := popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID)
+	}
+	assert.NoError(t, st.Finalize(depID))
+
+	task = popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID)
+		assert.Equal(t, task.Sequence, int64(2))
+	}
+	assert.Nil(t, popTask(st))
+
+	assert.NoError(t, st.Finalize(depID))
+
+	task = popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID2)
+		assert.Equal(t, task.ComponentID, comp.Id)
+	}
+
+	// the history of the previous generation is kept
+	history, err := st.GetHistory(depID)
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func testPurge(t *testing.T, st State) {
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, st.UpdateDeployment(&proto.Deployment{
+		Id: depID,
+	}))
+	assert.NoError(t, st.UpsertNode(&proto.Instance{
+		ID:           "i0",
+		DeploymentID: depID,
+	}))
+	assert.NoError(t, st.UpsertEvaluation(&proto.Evaluation{
+		Id:           "e0",
+		DeploymentID: depID,
+	}))
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	// only deleted deployments can be purged
+	assert.Error(t, st.Purge(depID))
+	assert.Error(t, st.Purge("unknown"))
+
+	_, err = st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	// the deletion is not applied yet
+	assert.Error(t, st.Purge(depID))
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+
+	assert.NoError(t, st.Purge(depID))
+
+	comp, err := st.ReadDeployment(depID)
+	assert.NoError(t, err)
+	assert.Nil(t, comp)
+
+	_, err = st.LoadNode("i0")
+	assert.Error(t, err)
+
+	eval, err := st.GetEvaluation("e0")
+	assert.NoError(t, err)
+	assert.Nil(t, eval)
+
+	deps, err := st.ListDeployments()
+	assert.NoError(t, err)
+	assert.Len(t, deps, 0)
+}
+
+func testDeleteRefuseDependents(t *testing.T, st State) {
+	depID := applyAndFinalize(t, st, "name1")
+	depID2 := applyAndFinalize(t, st, "name2", "name1")
+
+	dependsOn, dependencyOf, err := st.GetDependencies(depID)
+	assert.NoError(t, err)
+	assert.Empty(t, dependsOn)
+	assert.Equal(t, dependencyOf, []string{depID2})
+
+	dependsOn, dependencyOf, err = st.GetDependencies(depID2)
+	assert.NoError(t, err)
+	assert.Equal(t, dependsOn, []string{depID})
+	assert.Empty(t, dependencyOf)
+
+	// name2 depends on name1
+	_, err = st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.Error(t, err)
+
+	_, err = st.Apply(&proto.Component{
+		Name:   "name2",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	// the dependent is being deleted but it is not done yet
+	comp, err := st.Apply(&proto.Component{
+		Name:   "name1",
+		Action: proto.Component_DELETE,
+		Spec:   proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Status, proto.Component_BLOCKED)
+
+	task := popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID2)
+	}
+	assert.Nil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID2))
+
+	task = popTask(st)
+	if assert.NotNil(t, task) {
+		assert.Equal(t, task.DeploymentID, depID)
+	}
+}
+
+func testDeleteCascade(t *testing.T, st State) {
+	depID := applyAndFinalize(t, st, "name1")
+	depID2 := applyAndFinalize(t, st, "name2", "name1")
+	depID3 := applyAndFinalize(t, st, "name3", "name2")
+
+	_, err := st.Apply(&proto.Component{
+		Name:    "name1",
+		Action:  proto.Component_DELETE,
+		Spec:    proto.MustMarshalAny(&proto.ClusterSpec{}),
+		Cascade: true,
+	})
+	assert.NoError(t, err)
+
+	// the clusters are deleted in the reverse order of the dependencies
+	for _, id := range []string{depID3, depID2, depID} {
+		task := popTask(st)
+		if assert.NotNil(t, task) {
+			assert.Equal(t, task.DeploymentID, id)
+		}
+		assert.Nil(t, popTask(st))
+		assert.NoError(t, st.Finalize(id))
+	}
+}
+
+func testDependsOnCycle(t *testing.T, st State) {
+	// a cluster cannot depend on itself
+	_, err := st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name1"},
+		}),
+	})
+	assert.Error(t, err)
+
+	applyAndFinalize(t, st, "name1")
+	applyAndFinalize(t, st, "name2", "name1")
+	applyAndFinalize(t, st, "name3", "name2")
+
+	_, err = st.Apply(&proto.Component{
+		Name: "name1",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: []string{"name3"},
+		}),
+	})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "name1 -> name3 -> name2 -> name1")
+	}
+
+	// the dependencies of name2 can be changed
+	depID2, err := st.NameToDeployment("name2")
+	assert.NoError(t, err)
+
+	_, err = st.Apply(&proto.Component{
+		Name: "name2",
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{}),
+	})
+	assert.NoError(t, err)
+
+	dependsOn, _, err := st.GetDependencies(depID2)
+	assert.NoError(t, err)
+	assert.Empty(t, dependsOn)
+}
+
+// applyAndFinalize creates a cluster and applies it
+func applyAndFinalize(t *testing.T, st State, name string, dependsOn ...string) string {
+	_, err := st.Apply(&proto.Component{
+		Name: name,
+		Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+			DependsOn: dependsOn,
+		}),
+	})
+	assert.NoError(t, err)
+
+	depID, err := st.NameToDeployment(name)
+	assert.NoError(t, err)
+
+	assert.NotNil(t, popTask(st))
+	assert.NoError(t, st.Finalize(depID))
+	return depID
+}
+
+func testApplyExpectedSequence(t *testing.T, st State) {
+	apply := func(count, expected int64) (*proto.Component, error) {
+		return st.Apply(&proto.Component{
+			Name: "name1",
+			Spec: proto.MustMarshalAny(&proto.ClusterSpec{
+				Groups: []*proto.ClusterSpec_Group{
+					{Count: count},
+				},
+			}),
+			ExpectedSequence: expected,
+		})
+	}
+
+	// the component does not exist yet
+	_, err := apply(1, 1)
+	assert.Error(t, err)
+
+	comp, err := apply(1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(1))
+	assert.Zero(t, comp.ExpectedSequence)
+
+	comp, err = apply(2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, comp.Sequence, int64(2))
+
+	// two applies that expect the same sequence, the second one fails
+	_, err = apply(3, 1)
+	if assert.Error(t, err) {
+		conflict, ok := err.(*ConflictError)
+		if assert.True(t, ok) {
+			assert.Equal(t, conflict.Expected, int64(1))
+			assert.Equal(t, conflict.Current, int64(2))
+		}
+	}
+
+	depID, err := st.NameToDeployment("name1")
+	assert.NoError(t, err)
+
+	versions, err := st.GetComponentVersions(depID, comp.Id)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	// the deletion checks the sequence too
+	_, err = st.Apply(&proto.Component{
+		Name:             "name1",
+		Action:           proto.Component_DELETE,
+		Spec:             proto.MustMarshalAny(&proto.ClusterSpec{}),
+		ExpectedSequence: 1,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+// backendStatusTimeout is the maximum time to read the status of a
+// deployment from the backend
+var backendStatusTimeout = 10 * time.Second
+
+// StatusHandler is an optional interface for the handlers that report the
+// status of the clusters from the point of view of the backend
+type StatusHandler interface {
+	// BackendStatus returns the status of the deployment reported by the
+	// backend or nil if the backend does not report any
+	BackendStatus(ctx context.Context, dep *proto.Deployment) (*proto.BackendStatus, error)
+}
+
+// StatusRequest is the request to the Status function of a backend
+type StatusRequest struct {
+	Deployment *proto.Deployment
+
+	b *BaseOperator
+}
+
+// Running returns the running instances of the deployment
+func (r *StatusRequest) Running() []*proto.Instance {
+	res := []*proto.Instance{}
+	for _, i := range r.Deployment.Instances {
+		if i.Status == proto.Instance_RUNNING && i.DesiredStatus == proto.Instance_RUN {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// Client returns the client of the backend for the instance
+func (r *StatusRequest) Client(i *proto.Instance) (interface{}, error) {
+	return r.b.handler.Client(i)
+}
+
+// Exec runs a command in the instance
+func (r *StatusRequest) Exec(ctx context.Context, i *proto.Instance, path string, args ...string) (string, error) {
+	return r.b.exec(ctx, i, path, args...)
+}
+
+// BackendStatus implements the StatusHandler interface
+func (b *BaseOperator) BackendStatus(ctx context.Context, dep *proto.Deployment) (*proto.BackendStatus, error) {
+	statusFn := b.handler.Spec().Status
+	if statusFn == nil {
+		return nil, nil
+	}
+	req := &StatusRequest{
+		Deployment: dep,
+		b:          b,
+	}
+	if len(req.Running()) == 0 {
+		return nil, nil
+	}
+
+	// the clients of the backends are not always cancellable, the
+	// request returns without waiting for them once the context is done
+	type result struct {
+		status *proto.BackendStatus
+		err    error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		status, err := statusFn(ctx, req)
+		resCh <- result{status, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.status, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// exec runs a command in the instance with the control plane
+func (b *BaseOperator) exec(ctx context.Context, instance *proto.Instance, path string, args ...string) (string, error) {
+	executor, ok := b.cplane.(Executor)
+	if !ok {
+		return "", fmt.Errorf("the control plane cannot run commands")
+	}
+
+	// the exec call cannot be cancelled, it returns
+	// without waiting for it if the context is done
+	type result struct {
+		out string
+		err error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		out, err := executor.Exec(instance, path, args...)
+		resCh <- result{out, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.out, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// backendStatus returns the status of the deployment reported by the backend.
+// If the status cannot be read, the error is set in the status.
+func (s *Server) backendStatus(ctx context.Context, dep *proto.Deployment) *proto.BackendStatus {
+	if dep.Backend == "" {
+		// the cluster has not been handled yet
+		return nil
+	}
+	handler, err := s.GetHandler(dep.Backend)
+	if err != nil {
+		return &proto.BackendStatus{Error: err.Error()}
+	}
+	statusHandler, ok := handler.(StatusHandler)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, backendStatusTimeout)
+	defer cancel()
+
+	status, err := statusHandler.BackendStatus(ctx, dep)
+	if err != nil {
+		return &proto.BackendStatus{Error: err.Error()}
+	}
+	return status
+}
@@ -0,0 +1,89 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/teseraio/ensemble/operator/proto"
+)
+
+func TestServer_BackendStatus(t *testing.T) {
+	var statusErr error
+	var delay time.Duration
+
+	h := newMockHandler(&Spec{
+		Status: func(ctx context.Context, req *StatusRequest) (*proto.BackendStatus, error) {
+			time.Sleep(delay)
+			if statusErr != nil {
+				return nil, statusErr
+			}
+			return &proto.BackendStatus{
+				Healthy: true,
+				Attributes: map[string]string{
+					"leader": req.Running()[0].Name,
+				},
+			}, nil
+		},
+	})
+	h.name = "mock"
+
+	s := testServer(t, h)
+	s.handlers = map[string]Handler{
+		"mock": h,
+	}
+	srv := &service{s: s}
+
+	getStatus := func() *proto.BackendStatus {
+		dep, err := srv.GetDeployment(context.Background(), &proto.GetDeploymentReq{Cluster: "dep1"})
+		assert.NoError(t, err)
+		return dep.BackendStatus
+	}
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id: "dep1",
+	}))
+
+	// there is no status before the backend of the cluster is set
+	assert.Nil(t, getStatus())
+
+	assert.NoError(t, s.State.UpdateDeployment(&proto.Deployment{
+		Id:      "dep1",
+		Backend: "mock",
+	}))
+
+	// there is no status without running instances
+	assert.Nil(t, getStatus())
+
+	assert.NoError(t, s.UpsertInstance(&proto.Instance{
+		ID:           "i0",
+		Name:         "a-1",
+		DeploymentID: "dep1",
+		Status:       proto.Instance_RUNNING,
+	}))
+
+	status := getStatus()
+	assert.True(t, status.Healthy)
+	assert.Equal(t, status.Attributes["leader"], "a-1")
+
+	// the status is not stored
+	dep, err := s.State.LoadDeployment("dep1")
+	assert.NoError(t, err)
+	assert.Nil(t, dep.BackendStatus)
+
+	// the errors are reported in the status
+	statusErr = fmt.Errorf("connection refused")
+	assert.Equal(t, getStatus().Error, "connection refused")
+
+	// the backend does not block the request
+	statusErr = nil
+	delay = 100 * time.Millisecond
+
+	backendStatusTimeout = 10 * time.Millisecond
+	defer func() {
+		backendStatusTimeout = 10 * time.Second
+	}()
+	assert.Equal(t, getStatus().Error, context.DeadlineExceeded.Error())
+}
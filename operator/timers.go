@@ -0,0 +1,78 @@
+package operator
+
+import (
+	"sync"
+	"time"
+)
+
+// timerSet keeps at most one timer for each key. Setting a timer
+// replaces the previous one of the key.
+type timerSet struct {
+	lock    sync.Mutex
+	timers  map[string]*time.Timer
+	stopped bool
+}
+
+// set runs f once d has passed unless the timer of the key is
+// replaced or cancelled before
+func (t *timerSet) set(key string, d time.Duration, f func()) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.stopped {
+		return
+	}
+	if t.timers == nil {
+		t.timers = map[string]*time.Timer{}
+	}
+	if timer, ok := t.timers[key]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d, func() {
+		t.lock.Lock()
+		current := t.timers[key] == timer
+		if current {
+			delete(t.timers, key)
+		}
+		t.lock.Unlock()
+
+		if current {
+			f()
+		}
+	})
+	t.timers[key] = timer
+}
+
+// cancel stops the timer of the key if there is any
+func (t *timerSet) cancel(key string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if timer, ok := t.timers[key]; ok {
+		timer.Stop()
+		delete(t.timers, key)
+	}
+}
+
+// has returns true if there is a pending timer for the key
+func (t *timerSet) has(key string) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, ok := t.timers[key]
+	return ok
+}
+
+// stop cancels all the timers, no timer can be set afterwards
+func (t *timerSet) stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key, timer := range t.timers {
+		timer.Stop()
+		delete(t.timers, key)
+	}
+	t.stopped = true
+}
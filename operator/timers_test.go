@@ -0,0 +1,41 @@
+package operator
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimerSet(t *testing.T) {
+	var fired int32
+	inc := func() {
+		atomic.AddInt32(&fired, 1)
+	}
+
+	var timers timerSet
+
+	// the timer of a key replaces the previous one
+	for i := 0; i < 10; i++ {
+		timers.set("a", 10*time.Millisecond, inc)
+	}
+	assert.True(t, timers.has("a"))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, atomic.LoadInt32(&fired), int32(1))
+	assert.False(t, timers.has("a"))
+
+	// a cancelled timer does not fire
+	timers.set("a", 10*time.Millisecond, inc)
+	timers.cancel("a")
+
+	// and no timer is set once the set is stopped
+	timers.set("b", 10*time.Millisecond, inc)
+	timers.stop()
+	timers.set("c", 10*time.Millisecond, inc)
+	assert.False(t, timers.has("c"))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, atomic.LoadInt32(&fired), int32(1))
+}
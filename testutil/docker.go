@@ -135,13 +135,13 @@ func (c *Client) runProvider() {
 	fmt.Println("- run provider -")
 	fmt.Println(c.controlPlane)
 
-	ch := c.controlPlane.SubscribeInstanceUpdates()
+	sub := c.controlPlane.SubscribeInstanceUpdates()
 
 	go func() {
 		for {
-			msg := <-ch
-			if msg == nil {
-				panic("bad")
+			msg, err := sub.Next(context.Background())
+			if err != nil {
+				panic(err)
 			}
 			go c.handleInstanceMsg(msg)
 		}
@@ -281,15 +281,24 @@ func (c *Client) buildBody(node *proto.Instance) (string, error) {
 
 	// mount paths
 	if len(node.Mounts) != 0 {
-		dataDir := "/tmp/ensemble-" + node.ClusterName + "-" + node.Name
 		for _, mount := range node.Mounts {
-			localPath := dataDir + "-" + mount.Name
+			localPath := "/tmp/ensemble-" + node.ClusterName + "-" + node.VolumeName(mount)
 			if err := createIfNotExists(localPath); err != nil {
 				return "", err
 			}
 			binds = append(binds, fmt.Sprintf("%s:%s", localPath, mount.Path))
 		}
 	}
+	if node.Prev != "" {
+		// remove the volumes of the previous instance that were wiped
+		if prev, err := c.controlPlane.GetInstance(node.Prev); err == nil {
+			for _, mount := range node.WipedMounts(prev) {
+				if err := os.RemoveAll("/tmp/ensemble-" + prev.ClusterName + "-" + prev.VolumeName(mount)); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
 
 	if err := c.PullImage(ctx, image); err != nil {
 		return "", err
@@ -1,6 +1,7 @@
 package testutil
 
 import (
+	"context"
 	"strings"
 	"testing"
 	"time"
@@ -42,19 +43,21 @@ func TestProvider(t *testing.T, p operator.Provider) {
 }
 
 func readEvent(p operator.ControlPlane, t *testing.T) *proto.Instance {
-	ch := p.SubscribeInstanceUpdates()
+	sub := p.SubscribeInstanceUpdates()
+	defer sub.Close()
 
-	select {
-	case msg := <-ch:
-		instance, err := p.GetInstance(msg.InstanceID)
-		if err != nil {
-			t.Fatal(err)
-		}
-		return instance
-	case <-time.After(30 * time.Second):
-		t.Fatal("timeout")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	msg, err := sub.Next(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instance, err := p.GetInstance(msg.InstanceID)
+	if err != nil {
+		t.Fatal(err)
 	}
-	return nil
+	return instance
 }
 
 func waitForRunning(c operator.ControlPlane, t *testing.T) *proto.Instance {
@@ -70,24 +73,23 @@ func waitForStopped(c operator.ControlPlane, t *testing.T) *proto.Instance {
 }
 
 func waitForEvent(c operator.ControlPlane, t *testing.T, handler func(i *proto.Instance) bool) *proto.Instance {
-	doneCh := make(chan struct{})
-	go func() {
-		<-time.After(20 * time.Second)
-		close(doneCh)
-	}()
-	evnts := c.SubscribeInstanceUpdates()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	sub := c.SubscribeInstanceUpdates()
+	defer sub.Close()
+
 	for {
-		select {
-		case evnt := <-evnts:
-			instance, err := c.GetInstance(evnt.InstanceID)
-			if err != nil {
-				t.Fatal(err)
-			}
-			if handler(instance) {
-				return instance.Copy()
-			}
-		case <-doneCh:
-			t.Fatal("timeout")
+		evnt, err := sub.Next(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		instance, err := c.GetInstance(evnt.InstanceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if handler(instance) {
+			return instance.Copy()
 		}
 	}
 }
@@ -8,10 +8,9 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-hclog"
-	"github.com/teseraio/ensemble/lib/uuid"
 	"github.com/teseraio/ensemble/operator"
 	"github.com/teseraio/ensemble/operator/proto"
-	"github.com/teseraio/ensemble/operator/state/boltdb"
+	"github.com/teseraio/ensemble/operator/state/memdb"
 	"google.golang.org/grpc"
 )
 
@@ -26,8 +25,7 @@ var testPortRangeBegin = uint64(6000)
 type TestServer struct {
 	t      *testing.T
 	srv    *operator.Server
-	state  *boltdb.BoltDB
-	path   string
+	state  *memdb.MemDB
 	docker *Client
 	clt    proto.EnsembleServiceClient
 }
@@ -48,17 +46,10 @@ func (t *TestServer) WaitForTask(id string) {
 func (t *TestServer) Close() {
 	t.srv.Stop()
 	t.docker.Clean()
-	if err := os.Remove(t.path); err != nil {
-		t.t.Fatal(err)
-	}
 }
 
 func TestOperator(t *testing.T, factories ...operator.HandlerFactory) *TestServer {
-	path := "/tmp/db-" + uuid.UUID()
-
-	state, err := boltdb.Factory(map[string]interface{}{
-		"path": path,
-	})
+	state, err := memdb.Factory(nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -100,7 +91,6 @@ func TestOperator(t *testing.T, factories ...operator.HandlerFactory) *TestServe
 		srv:    srv,
 		state:  state,
 		docker: provider,
-		path:   path,
 		clt:    proto.NewEnsembleServiceClient(conn),
 	}
 	return tt